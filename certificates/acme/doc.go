@@ -0,0 +1,13 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package acme obtains certificates for the domains of service groups on
+// KraftCloud from an ACME certificate authority, e.g. Let's Encrypt, uploads
+// them and renews them before they expire.
+//
+// Domains are validated either with the HTTP-01 challenge, which is answered
+// by a temporary instance in the service group of the domain, or with the
+// DNS-01 challenge, which is answered by a DNSProvider.
+package acme
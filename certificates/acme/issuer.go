@@ -0,0 +1,375 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package acme
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/acme"
+
+	"sdk.kraft.cloud/certificates"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/domains"
+)
+
+// Renewal is the outcome of obtaining a new certificate for a domain.
+type Renewal struct {
+	// FQDN is the fully qualified name of the domain.
+	FQDN string `json:"fqdn"`
+
+	// Previous is the certificate which secured the domain before, if any.
+	Previous *domains.Certificate `json:"previous,omitempty"`
+
+	// Domain is the domain after it was rebound to the new certificate.
+	Domain *domains.Domain `json:"domain,omitempty"`
+
+	// Err is set if no new certificate could be obtained, uploaded or bound.
+	Err error `json:"-"`
+}
+
+// Issuer obtains certificates for the domains of service groups from an ACME
+// certificate authority, uploads them and rebinds the domains to them.
+type Issuer struct {
+	certificates certificates.CertificatesService
+	domains      *domains.Manager
+	solver       Solver
+	client       *acme.Client
+
+	contact       []string
+	renewBefore   time.Duration
+	renewInterval time.Duration
+	handler       func([]Renewal, error)
+
+	mu         sync.Mutex
+	registered bool
+}
+
+// NewIssuer instantiates a new Issuer which uploads certificates with the given
+// client, rebinds domains with the given manager and answers challenges with
+// the given solver.
+func NewIssuer(certs certificates.CertificatesService, manager *domains.Manager, solver Solver, opts ...IssuerOption) *Issuer {
+	i := &Issuer{
+		certificates: certs,
+		domains:      manager,
+		solver:       solver,
+		client: &acme.Client{
+			DirectoryURL: DefaultDirectoryURL,
+			UserAgent:    "kraftcloud-go",
+		},
+		renewBefore:   DefaultRenewBefore,
+		renewInterval: DefaultRenewInterval,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	if i.renewInterval <= 0 {
+		i.renewInterval = DefaultRenewInterval
+	}
+
+	return i
+}
+
+// Obtain obtains a certificate for the given names from the certificate
+// authority.  The first name becomes the common name of the certificate.
+func (i *Issuer) Obtain(ctx context.Context, names ...string) (*certificates.Bundle, error) {
+	return i.obtain(ctx, kcclient.Identity{}, names)
+}
+
+// Issue obtains a certificate for the domain with the given name, uploads it
+// and rebinds the domain to it.
+func (i *Issuer) Issue(ctx context.Context, fqdn string) (*domains.Domain, error) {
+	domain, err := i.domains.Get(ctx, fqdn)
+	if domain == nil {
+		return nil, err
+	}
+
+	return i.issue(ctx, domain)
+}
+
+// Renew issues new certificates for the domains with the given names whose
+// certificates expire within the renewal window, judged by their NotAfter
+// time.  Domains which are secured by a certificate of the platform are issued
+// a certificate.  Without names, all domains with certificates are checked.
+// Domains which could only be listed partially are still renewed, and the
+// listing error is returned along with the renewals.
+func (i *Issuer) Renew(ctx context.Context, fqdns ...string) ([]Renewal, error) {
+	var (
+		renewals []Renewal
+		errs     []error
+		deadline = time.Now().Add(i.renewBefore)
+	)
+
+	list, err := i.domains.List(ctx)
+	if err != nil {
+		if list == nil {
+			return nil, fmt.Errorf("listing domains: %w", err)
+		}
+		errs = append(errs, fmt.Errorf("listing domains: %w", err))
+	}
+
+	for _, domain := range list {
+		if !selected(domain, fqdns) || !due(domain, deadline) {
+			continue
+		}
+
+		renewal := Renewal{
+			FQDN:     domain.FQDN,
+			Previous: domain.Certificate,
+		}
+
+		renewal.Domain, renewal.Err = i.issue(ctx, &domain)
+		if renewal.Err != nil {
+			errs = append(errs, renewal.Err)
+		}
+
+		renewals = append(renewals, renewal)
+	}
+
+	return renewals, errors.Join(errs...)
+}
+
+// Run calls Renew at the renewal interval until the context is done.
+func (i *Issuer) Run(ctx context.Context, fqdns ...string) error {
+	ticker := time.NewTicker(i.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		renewals, err := i.Renew(ctx, fqdns...)
+		if i.handler != nil {
+			i.handler(renewals, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// issue obtains a certificate for the domain, uploads it and rebinds the
+// domain to it.
+func (i *Issuer) issue(ctx context.Context, domain *domains.Domain) (*domains.Domain, error) {
+	bundle, err := i.obtain(ctx, domain.ServiceGroup, []string{strings.TrimSuffix(domain.FQDN, ".")})
+	if err != nil {
+		return nil, fmt.Errorf("obtaining certificate for '%s': %w", domain.FQDN, err)
+	}
+
+	req, err := bundle.CreateRequest(certificateName(domain.FQDN, bundle.Leaf()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := i.certificates.Create(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("uploading certificate for '%s': %w", domain.FQDN, err)
+	}
+
+	cert, err := resp.FirstOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("uploading certificate for '%s': %w", domain.FQDN, err)
+	}
+
+	return i.domains.Rebind(ctx, domain.FQDN, kcclient.ByUUID(cert.UUID))
+}
+
+// obtain places an order for the names, answers its challenges and returns the
+// issued certificate.
+func (i *Issuer) obtain(ctx context.Context, group kcclient.Identity, names []string) (*certificates.Bundle, error) {
+	if len(names) == 0 {
+		return nil, errors.New("requires at least one name")
+	}
+
+	if err := i.register(ctx); err != nil {
+		return nil, err
+	}
+
+	order, err := i.client.AuthorizeOrder(ctx, acme.DomainIDs(names...))
+	if err != nil {
+		return nil, fmt.Errorf("placing order: %w", err)
+	}
+
+	for _, url := range order.AuthzURLs {
+		if err := i.authorize(ctx, group, url); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := i.client.WaitOrder(ctx, order.URI); err != nil {
+		return nil, fmt.Errorf("waiting for order: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating private key: %w", err)
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: names[0]},
+		DNSNames: names,
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate request: %w", err)
+	}
+
+	der, _, err := i.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("finalizing order: %w", err)
+	}
+
+	bundle := &certificates.Bundle{Key: key}
+	for _, raw := range der {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing issued certificate: %w", err)
+		}
+		bundle.Chain = append(bundle.Chain, cert)
+	}
+
+	return bundle, nil
+}
+
+// authorize answers the challenge of the authorization with the given URL which
+// matches the solver and waits until the authorization is valid.
+func (i *Issuer) authorize(ctx context.Context, group kcclient.Identity, url string) error {
+	authz, err := i.client.GetAuthorization(ctx, url)
+	if err != nil {
+		return fmt.Errorf("getting authorization: %w", err)
+	}
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == i.solver.Type() {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("no %s challenge offered for '%s'", i.solver.Type(), authz.Identifier.Value)
+	}
+
+	ch := Challenge{
+		Type:         chal.Type,
+		Domain:       authz.Identifier.Value,
+		ServiceGroup: group,
+		Token:        chal.Token,
+	}
+
+	switch chal.Type {
+	case ChallengeDNS01:
+		ch.Value, err = i.client.DNS01ChallengeRecord(chal.Token)
+	default:
+		ch.Value, err = i.client.HTTP01ChallengeResponse(chal.Token)
+	}
+	if err != nil {
+		return err
+	}
+
+	cleanup, err := solve(ctx, i.solver, ch)
+	if err != nil {
+		return fmt.Errorf("presenting %s challenge for '%s': %w", ch.Type, ch.Domain, err)
+	}
+
+	if _, err = i.client.Accept(ctx, chal); err == nil {
+		_, err = i.client.WaitAuthorization(ctx, url)
+	}
+	if err != nil {
+		err = fmt.Errorf("validating %s challenge for '%s': %w", ch.Type, ch.Domain, err)
+	}
+
+	if cerr := cleanup(context.WithoutCancel(ctx)); cerr != nil {
+		err = errors.Join(err, fmt.Errorf("cleaning up %s challenge for '%s': %w", ch.Type, ch.Domain, cerr))
+	}
+
+	return err
+}
+
+// register registers the account at the certificate authority once.
+func (i *Issuer) register(ctx context.Context) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.registered {
+		return nil
+	}
+
+	if i.client.Key == nil {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return fmt.Errorf("generating account key: %w", err)
+		}
+		i.client.Key = key
+	}
+
+	_, err := i.client.Register(ctx, &acme.Account{Contact: i.contact}, acme.AcceptTOS)
+	if err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("registering account: %w", err)
+	}
+
+	i.registered = true
+	return nil
+}
+
+// selected reports whether the domain is one of the given names, or whether it
+// has a certificate if no names are given.
+func selected(domain domains.Domain, fqdns []string) bool {
+	if len(fqdns) == 0 {
+		return domain.Certificate != nil
+	}
+
+	for _, fqdn := range fqdns {
+		if normalize(fqdn) == normalize(domain.FQDN) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// due reports whether the certificate of the domain expires before the
+// deadline.  Certificates whose expiry is unknown, e.g. because they are still
+// pending, are not due.
+func due(domain domains.Domain, deadline time.Time) bool {
+	cert := domain.Certificate
+	if cert == nil {
+		return true
+	}
+	if cert.NotAfter.IsZero() {
+		return false
+	}
+
+	return cert.NotAfter.Before(deadline)
+}
+
+// certificateName returns the name under which the certificate for the domain
+// is uploaded, e.g. "acme-example-com-20250102150405".
+func certificateName(fqdn string, leaf *x509.Certificate) string {
+	name := strings.ReplaceAll(normalize(fqdn), "*", "wildcard")
+	name = strings.ReplaceAll(name, ".", "-")
+
+	return fmt.Sprintf("acme-%s-%s", name, leaf.NotBefore.UTC().Format("20060102150405"))
+}
+
+// normalize returns the name of the domain in lower case and without a
+// trailing dot.
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,392 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package acme_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	xacme "golang.org/x/crypto/acme"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/certificates/acme"
+	"sdk.kraft.cloud/domains"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+// records is a DNSProvider which keeps TXT records in memory and, if set,
+// mirrors them to the challenge test server of Pebble.
+type records struct {
+	mu      sync.Mutex
+	txt     map[string]string
+	pebble  string
+	cleaned int
+}
+
+func (r *records) SetTXT(ctx context.Context, name, value string) error {
+	r.mu.Lock()
+	r.txt[name] = value
+	r.mu.Unlock()
+
+	return r.mirror("/set-txt", fmt.Sprintf(`{"host":%q,"value":%q}`, name, value))
+}
+
+func (r *records) DeleteTXT(ctx context.Context, name, value string) error {
+	r.mu.Lock()
+	delete(r.txt, name)
+	r.cleaned++
+	r.mu.Unlock()
+
+	return r.mirror("/clear-txt", fmt.Sprintf(`{"host":%q}`, name))
+}
+
+func (r *records) mirror(path, body string) error {
+	if r.pebble == "" {
+		return nil
+	}
+
+	resp, err := http.Post(r.pebble+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// fakeCA is a minimal ACME server which validates dns-01 challenges against
+// the records and issues certificates from a self-signed root.
+type fakeCA struct {
+	*httptest.Server
+
+	key  *ecdsa.PrivateKey
+	root *x509.Certificate
+
+	mu     sync.Mutex
+	tokens map[string]string
+	valid  map[string]bool
+	certs  map[string][]byte
+}
+
+func newFakeCA(rec *records, account *ecdsa.PrivateKey) *fakeCA {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Fake ACME Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, _ := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	root, _ := x509.ParseCertificate(der)
+
+	ca := &fakeCA{
+		key:    key,
+		root:   root,
+		tokens: make(map[string]string),
+		valid:  make(map[string]bool),
+		certs:  make(map[string][]byte),
+	}
+
+	expected := &xacme.Client{Key: account}
+
+	ca.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ca.mu.Lock()
+		defer ca.mu.Unlock()
+
+		w.Header().Set("Replay-Nonce", fmt.Sprint(time.Now().UnixNano()))
+		base := "http://" + r.Host
+
+		var jws struct{ Payload string }
+		_ = json.NewDecoder(r.Body).Decode(&jws)
+		payload, _ := base64.RawURLEncoding.DecodeString(jws.Payload)
+
+		kind, id, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+		switch kind {
+		case "directory":
+			fmt.Fprintf(w, `{"newNonce":"%[1]s/nonce","newAccount":"%[1]s/account","newOrder":"%[1]s/order"}`, base)
+
+		case "nonce":
+
+		case "account":
+			w.Header().Set("Location", base+"/account/1")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"status":"valid"}`)
+
+		case "order":
+			status := http.StatusOK
+			if id == "" {
+				var req struct{ Identifiers []struct{ Value string } }
+				_ = json.Unmarshal(payload, &req)
+				id = req.Identifiers[0].Value
+				ca.tokens[id] = fmt.Sprintf("token-%d", time.Now().UnixNano())
+				status = http.StatusCreated
+			}
+			ca.order(w, status, base, id)
+
+		case "authz", "challenge":
+			if kind == "challenge" {
+				value, _ := expected.DNS01ChallengeRecord(ca.tokens[id])
+				rec.mu.Lock()
+				ca.valid[id] = rec.txt["_acme-challenge."+id+"."] == value
+				rec.mu.Unlock()
+			}
+
+			status := "pending"
+			if ca.valid[id] {
+				status = "valid"
+			}
+			fmt.Fprintf(w, `{"status":%q,"identifier":{"type":"dns","value":%q},"challenges":[{"type":"dns-01","status":%[1]q,"url":"%[3]s/challenge/%[2]s","token":%[4]q}]}`,
+				status, id, base, ca.tokens[id])
+
+		case "finalize":
+			var req struct{ CSR string }
+			_ = json.Unmarshal(payload, &req)
+			raw, _ := base64.RawURLEncoding.DecodeString(req.CSR)
+			csr, err := x509.ParseCertificateRequest(raw)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			leaf := &x509.Certificate{
+				SerialNumber: big.NewInt(time.Now().UnixNano()),
+				Subject:      csr.Subject,
+				DNSNames:     csr.DNSNames,
+				NotBefore:    time.Now().Add(-time.Minute),
+				NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+			}
+			der, _ := x509.CreateCertificate(rand.Reader, leaf, ca.root, csr.PublicKey, ca.key)
+			ca.certs[id] = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+				pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.root.Raw})...)
+			ca.order(w, http.StatusOK, base, id)
+
+		case "cert":
+			w.Header().Set("Content-Type", "application/pem-certificate-chain")
+			_, _ = w.Write(ca.certs[id])
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	return ca
+}
+
+func (ca *fakeCA) order(w http.ResponseWriter, code int, base, id string) {
+	status, cert := "pending", ""
+	switch {
+	case ca.certs[id] != nil:
+		status, cert = "valid", base+"/cert/"+id
+	case ca.valid[id]:
+		status = "ready"
+	}
+
+	w.Header().Set("Location", base+"/order/"+id)
+	w.WriteHeader(code)
+	fmt.Fprintf(w, `{"status":%q,"identifiers":[{"type":"dns","value":%q}],"authorizations":["%[3]s/authz/%[2]s"],"finalize":"%[3]s/finalize/%[2]s","certificate":%[4]q}`,
+		status, id, base, cert)
+}
+
+// serveCertificates serves certificates which expire in a day unless they
+// were uploaded, and stores uploaded certificates.  The certificate "gone"
+// does not exist.
+func serveCertificates(api *fakeapi.Server, certs map[string]*x509.Certificate) {
+	api.Handle(http.MethodGet, "/certificates", "certificates", func(r *fakeapi.Request) []string {
+		var entries []string
+		for i := range r.Items {
+			uuid := r.String(i, "uuid")
+			if uuid == "gone" {
+				entries = append(entries, `{"status":"error","uuid":"gone","message":"certificate not found","error":8}`)
+				continue
+			}
+			notAfter := time.Now().Add(24 * time.Hour)
+			if cert, ok := certs[uuid]; ok {
+				notAfter = cert.NotAfter
+			}
+			entries = append(entries, fmt.Sprintf(`{"status":"success","uuid":%q,"name":%[1]q,"common_name":"example.com",`+
+				`"state":"valid","not_after":%q}`, uuid, notAfter.Format(time.RFC3339)))
+		}
+		return entries
+	})
+
+	api.Handle(http.MethodPost, "/certificates", "certificates", func(r *fakeapi.Request) []string {
+		var req struct{ Name, Chain string }
+		_ = json.Unmarshal(r.Body, &req)
+		block, _ := pem.Decode([]byte(req.Chain))
+		cert, _ := x509.ParseCertificate(block.Bytes)
+		uuid := fmt.Sprintf("c%d", len(certs)+1)
+		certs[uuid] = cert
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q}`, uuid, req.Name)}
+	})
+}
+
+func TestRenew(t *testing.T) {
+	account, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rec := &records{txt: make(map[string]string)}
+
+	opts := []acme.IssuerOption{acme.WithAccountKey(account)}
+
+	// Run against a local Pebble server with its challenge test server as DNS
+	// resolver if configured, e.g. PEBBLE_DIRECTORY=https://localhost:14000/dir
+	// and PEBBLE_CHALLTESTSRV=http://localhost:8055.
+	if dir := os.Getenv("PEBBLE_DIRECTORY"); dir != "" {
+		rec.pebble = os.Getenv("PEBBLE_CHALLTESTSRV")
+		opts = append(opts,
+			acme.WithDirectoryURL(dir),
+			acme.WithHTTPClient(&http.Client{Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			}}),
+		)
+	} else {
+		ca := newFakeCA(rec, account)
+		defer ca.Close()
+		opts = append(opts, acme.WithDirectoryURL(ca.URL+"/directory"))
+	}
+
+	api := fakeapi.New(t)
+	api.ServeServiceGroup(&fakeapi.ServiceGroup{
+		UUID:    "g1",
+		Name:    "web",
+		Domains: []fakeapi.Domain{{FQDN: "example.com.", Certificate: "old"}},
+	})
+	serveCertificates(api, make(map[string]*x509.Certificate))
+
+	certs := kraftcloud.NewCertificatesClient().WithMetro(api.URL)
+	manager := domains.NewManager(kraftcloud.NewServicesClient().WithMetro(api.URL), certs)
+	issuer := acme.NewIssuer(certs, manager, acme.NewDNS01Solver(rec), opts...)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	renewals, err := issuer.Renew(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(renewals) != 1 || renewals[0].Previous.UUID != "old" {
+		t.Fatalf("expected the expiring certificate to be renewed, got %+v", renewals)
+	}
+	if cert := renewals[0].Domain.Certificate; cert.UUID != "c1" || time.Until(cert.NotAfter) < acme.DefaultRenewBefore {
+		t.Errorf("expected the domain to be rebound to the new certificate, got %+v", cert)
+	}
+	if len(rec.txt) != 0 || rec.cleaned != 1 {
+		t.Errorf("expected the challenge record to be cleaned up, got %v", rec.txt)
+	}
+
+	renewals, err = issuer.Renew(ctx)
+	if err != nil || len(renewals) != 0 {
+		t.Errorf("expected no renewal of a fresh certificate, got %+v, %v", renewals, err)
+	}
+}
+
+func TestRenewPartialList(t *testing.T) {
+	account, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rec := &records{txt: make(map[string]string)}
+
+	ca := newFakeCA(rec, account)
+	defer ca.Close()
+
+	api := fakeapi.New(t)
+	api.ServeServiceGroup(&fakeapi.ServiceGroup{
+		UUID: "g1",
+		Name: "web",
+		Domains: []fakeapi.Domain{
+			{FQDN: "example.com.", Certificate: "old"},
+			{FQDN: "broken.example.com.", Certificate: "gone"},
+		},
+	})
+	serveCertificates(api, make(map[string]*x509.Certificate))
+
+	certs := kraftcloud.NewCertificatesClient().WithMetro(api.URL)
+	manager := domains.NewManager(kraftcloud.NewServicesClient().WithMetro(api.URL), certs)
+	issuer := acme.NewIssuer(certs, manager, acme.NewDNS01Solver(rec),
+		acme.WithAccountKey(account),
+		acme.WithDirectoryURL(ca.URL+"/directory"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	renewals, err := issuer.Renew(ctx)
+	if err == nil || !strings.Contains(err.Error(), "listing domains") {
+		t.Errorf("expected the listing error to be returned, got %v", err)
+	}
+	if len(renewals) != 1 || renewals[0].FQDN != "example.com." || renewals[0].Err != nil {
+		t.Errorf("expected the listed domain to be renewed, got %+v", renewals)
+	}
+
+	// A non-positive interval falls back to the default instead of panicking.
+	done, stop := context.WithCancel(ctx)
+	stop()
+
+	issuer = acme.NewIssuer(certs, manager, acme.NewDNS01Solver(rec), acme.WithRenewInterval(0))
+	if err := issuer.Run(done); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+}
+
+func TestHTTP01Solver(t *testing.T) {
+	var created, deleted []map[string]any
+
+	const instance = `{"status":"success","uuid":"i1","name":"acme","state":"running"}`
+
+	api := fakeapi.New(t)
+	api.Handle(http.MethodPost, "/instances", "instances", func(r *fakeapi.Request) []string {
+		var item map[string]any
+		_ = json.Unmarshal(r.Body, &item)
+		created = append(created, item)
+		return []string{instance}
+	})
+	api.Handle(http.MethodDelete, "/instances", "instances", func(r *fakeapi.Request) []string {
+		deleted = append(deleted, r.Items...)
+		return []string{instance}
+	})
+
+	solver := acme.NewHTTP01Solver(kraftcloud.NewInstancesClient().WithMetro(api.URL), "acme-responder:latest")
+	ch := acme.Challenge{Domain: "example.com", Token: "t1", Value: "t1.key"}
+
+	if err := solver.Present(context.Background(), ch); err == nil {
+		t.Error("expected an error for a domain without service group")
+	}
+
+	ch.ServiceGroup.UUID = "g1"
+	if err := solver.Present(context.Background(), ch); err != nil {
+		t.Fatal(err)
+	}
+	if err := solver.CleanUp(context.Background(), ch); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(created) != 1 || len(deleted) != 1 || deleted[0]["uuid"] != "i1" {
+		t.Fatalf("expected a single instance to be created and deleted, got %v and %v", created, deleted)
+	}
+
+	env, _ := created[0]["env"].(map[string]any)
+	group, _ := created[0]["service_group"].(map[string]any)
+	if env[acme.EnvChallengePath] != "/.well-known/acme-challenge/t1" || env[acme.EnvChallengeResponse] != "t1.key" || group["uuid"] != "g1" {
+		t.Errorf("expected the challenge to be passed to the instance in the service group, got %v", created[0])
+	}
+}
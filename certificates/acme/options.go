@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package acme
+
+import (
+	"crypto"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	// DefaultDirectoryURL is the default directory of the certificate
+	// authority, the production environment of Let's Encrypt.
+	DefaultDirectoryURL = acme.LetsEncryptURL
+
+	// DefaultRenewBefore is the default time before the expiry of a
+	// certificate at which it is renewed.
+	DefaultRenewBefore = 30 * 24 * time.Hour
+
+	// DefaultRenewInterval is the default interval at which Run checks whether
+	// certificates need to be renewed.
+	DefaultRenewInterval = 12 * time.Hour
+)
+
+// IssuerOption is an option function used during initialization of an
+// Issuer.
+type IssuerOption func(*Issuer)
+
+// WithDirectoryURL sets the URL of the directory of the certificate authority,
+// e.g. the staging environment of Let's Encrypt or a local Pebble server.
+func WithDirectoryURL(url string) IssuerOption {
+	return func(i *Issuer) {
+		i.client.DirectoryURL = url
+	}
+}
+
+// WithAccountKey sets the key of the account at the certificate authority.  By
+// default, a new account is registered with a generated key.
+func WithAccountKey(key crypto.Signer) IssuerOption {
+	return func(i *Issuer) {
+		i.client.Key = key
+	}
+}
+
+// WithContact sets the email addresses which the certificate authority may
+// contact about the account, e.g. before certificates expire.
+func WithContact(emails ...string) IssuerOption {
+	return func(i *Issuer) {
+		for _, email := range emails {
+			i.contact = append(i.contact, "mailto:"+email)
+		}
+	}
+}
+
+// WithHTTPClient sets the client which is used to communicate with the
+// certificate authority.
+func WithHTTPClient(hc *http.Client) IssuerOption {
+	return func(i *Issuer) {
+		i.client.HTTPClient = hc
+	}
+}
+
+// WithRenewBefore sets the time before the expiry of a certificate at which it
+// is renewed.
+func WithRenewBefore(d time.Duration) IssuerOption {
+	return func(i *Issuer) {
+		i.renewBefore = d
+	}
+}
+
+// WithRenewInterval sets the interval at which Run checks whether
+// certificates need to be renewed.  Non-positive intervals are replaced by
+// DefaultRenewInterval.
+func WithRenewInterval(interval time.Duration) IssuerOption {
+	return func(i *Issuer) {
+		i.renewInterval = interval
+	}
+}
+
+// WithRenewalHandler sets a function which is called with the outcome of
+// every check of Run, i.e. the attempted renewals and the error, if any.
+func WithRenewalHandler(fn func([]Renewal, error)) IssuerOption {
+	return func(i *Issuer) {
+		i.handler = fn
+	}
+}
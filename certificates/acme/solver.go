@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package acme
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+const (
+	// ChallengeHTTP01 is the type of the challenge which is answered with an
+	// HTTP response on port 80 of the domain.
+	ChallengeHTTP01 = "http-01"
+
+	// ChallengeDNS01 is the type of the challenge which is answered with a TXT
+	// record of the domain.
+	ChallengeDNS01 = "dns-01"
+)
+
+const (
+	// EnvChallengePath is the environment variable which holds the path under
+	// which the temporary instance of the HTTP01Solver must answer.
+	EnvChallengePath = "ACME_CHALLENGE_PATH"
+
+	// EnvChallengeResponse is the environment variable which holds the body
+	// with which the temporary instance of the HTTP01Solver must answer.
+	EnvChallengeResponse = "ACME_CHALLENGE_RESPONSE"
+)
+
+// Challenge is a challenge of the certificate authority which proves control
+// over a domain.
+type Challenge struct {
+	// Type of the challenge, e.g. ChallengeDNS01.
+	Type string
+
+	// Domain is the name of the domain which is validated.  For wildcard
+	// certificates, it is the name without the leading "*.".
+	Domain string
+
+	// ServiceGroup is the service group which serves the domain, if known.
+	ServiceGroup kcclient.Identity
+
+	// Token is the token of the challenge.
+	Token string
+
+	// Value is the response to the challenge: the body of the HTTP response
+	// for ChallengeHTTP01 and the value of the TXT record for ChallengeDNS01.
+	Value string
+}
+
+// Path returns the path under which the response to an HTTP-01 challenge is
+// requested.
+func (c Challenge) Path() string {
+	return "/.well-known/acme-challenge/" + c.Token
+}
+
+// Record returns the name of the TXT record which answers a DNS-01
+// challenge.
+func (c Challenge) Record() string {
+	return "_acme-challenge." + c.Domain + "."
+}
+
+// Solver answers challenges of a single type.
+type Solver interface {
+	// Type returns the type of the challenges which the solver answers.
+	Type() string
+
+	// Present makes the response to the challenge available.
+	Present(ctx context.Context, ch Challenge) error
+
+	// CleanUp removes the response to the challenge after it was validated.
+	CleanUp(ctx context.Context, ch Challenge) error
+}
+
+// DNSProvider manages TXT records at a DNS hosting provider.
+type DNSProvider interface {
+	// SetTXT adds a TXT record with the given fully qualified name and value.
+	// Other records with the same name must be kept.
+	SetTXT(ctx context.Context, name, value string) error
+
+	// DeleteTXT removes the TXT record with the given name and value.
+	DeleteTXT(ctx context.Context, name, value string) error
+}
+
+// DNS01Solver answers DNS-01 challenges with TXT records of a DNSProvider.
+type DNS01Solver struct {
+	provider DNSProvider
+}
+
+// NewDNS01Solver instantiates a new DNS01Solver which uses the given
+// provider.
+func NewDNS01Solver(provider DNSProvider) *DNS01Solver {
+	return &DNS01Solver{provider: provider}
+}
+
+// Type implements Solver.
+func (s *DNS01Solver) Type() string {
+	return ChallengeDNS01
+}
+
+// Present implements Solver.
+func (s *DNS01Solver) Present(ctx context.Context, ch Challenge) error {
+	if err := s.provider.SetTXT(ctx, ch.Record(), ch.Value); err != nil {
+		return fmt.Errorf("setting TXT record '%s': %w", ch.Record(), err)
+	}
+
+	return nil
+}
+
+// CleanUp implements Solver.
+func (s *DNS01Solver) CleanUp(ctx context.Context, ch Challenge) error {
+	if err := s.provider.DeleteTXT(ctx, ch.Record(), ch.Value); err != nil {
+		return fmt.Errorf("deleting TXT record '%s': %w", ch.Record(), err)
+	}
+
+	return nil
+}
+
+// HTTP01Solver answers HTTP-01 challenges with a temporary instance in the
+// service group which serves the domain.
+//
+// The instance is created from an image which answers requests for the path
+// in EnvChallengePath with the body in EnvChallengeResponse.  Requests to the
+// domain are balanced across all instances of the service group, so the other
+// instances must either be stopped or answer the challenge as well, e.g. by
+// proxying /.well-known/acme-challenge/ to the private FQDN of the temporary
+// instance.  Where this is not feasible, use a DNS01Solver.
+type HTTP01Solver struct {
+	instances instances.InstancesService
+	image     string
+
+	mu      sync.Mutex
+	pending map[string]string
+}
+
+// NewHTTP01Solver instantiates a new HTTP01Solver which creates instances
+// from the given image with the given client.
+func NewHTTP01Solver(inst instances.InstancesService, image string) *HTTP01Solver {
+	return &HTTP01Solver{
+		instances: inst,
+		image:     image,
+		pending:   make(map[string]string),
+	}
+}
+
+// Type implements Solver.
+func (s *HTTP01Solver) Type() string {
+	return ChallengeHTTP01
+}
+
+// Present implements Solver.
+func (s *HTTP01Solver) Present(ctx context.Context, ch Challenge) error {
+	group := ch.ServiceGroup
+	if group.UUID == "" && group.Name == "" {
+		return fmt.Errorf("domain '%s' is not served by a service group", ch.Domain)
+	}
+
+	autostart := true
+	waitTimeoutMs := 10000
+
+	req := instances.CreateRequest{
+		Image: &s.image,
+		Env: map[string]string{
+			EnvChallengePath:     ch.Path(),
+			EnvChallengeResponse: ch.Value,
+		},
+		ServiceGroup:  &instances.CreateRequestServiceGroup{},
+		Autostart:     &autostart,
+		WaitTimeoutMs: &waitTimeoutMs,
+	}
+	if group.UUID != "" {
+		req.ServiceGroup.UUID = &group.UUID
+	} else {
+		req.ServiceGroup.Name = &group.Name
+	}
+
+	resp, err := s.instances.Create(ctx, req)
+	if err != nil {
+		return fmt.Errorf("creating challenge instance: %w", err)
+	}
+
+	inst, err := resp.FirstOrErr()
+	if err != nil {
+		return fmt.Errorf("creating challenge instance: %w", err)
+	}
+
+	s.mu.Lock()
+	s.pending[ch.Token] = inst.UUID
+	s.mu.Unlock()
+
+	if inst.State != string(instances.InstanceStateRunning) {
+		return fmt.Errorf("challenge instance '%s' is %s, not %s", inst.Name, inst.State, instances.InstanceStateRunning)
+	}
+
+	return nil
+}
+
+// CleanUp implements Solver.
+func (s *HTTP01Solver) CleanUp(ctx context.Context, ch Challenge) error {
+	s.mu.Lock()
+	uuid, ok := s.pending[ch.Token]
+	delete(s.pending, ch.Token)
+	s.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	resp, err := s.instances.Delete(ctx, kcclient.ByUUID(uuid))
+	if err == nil {
+		_, err = resp.FirstOrErr()
+	}
+	if err != nil {
+		return fmt.Errorf("deleting challenge instance: %w", err)
+	}
+
+	return nil
+}
+
+// solve presents the challenge with the solver and returns a function which
+// cleans it up.
+func solve(ctx context.Context, solver Solver, ch Challenge) (func(context.Context) error, error) {
+	cleanup := func(ctx context.Context) error {
+		return solver.CleanUp(ctx, ch)
+	}
+
+	if err := solver.Present(ctx, ch); err != nil {
+		return nil, errors.Join(err, cleanup(ctx))
+	}
+
+	return cleanup, nil
+}
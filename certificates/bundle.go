@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package certificates
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// Bundle is a parsed certificate chain together with the private key of its
+// leaf certificate.
+type Bundle struct {
+	// Chain contains the certificates in the order in which they are served,
+	// starting with the leaf certificate.
+	Chain []*x509.Certificate
+
+	// Key is the private key of the leaf certificate.
+	Key crypto.Signer
+}
+
+// Leaf returns the leaf certificate of the chain.
+func (b *Bundle) Leaf() *x509.Certificate {
+	if len(b.Chain) == 0 {
+		return nil
+	}
+
+	return b.Chain[0]
+}
+
+// CommonName returns the name for which the leaf certificate is issued: its
+// common name or, if it has none, its first DNS name.
+func (b *Bundle) CommonName() string {
+	leaf := b.Leaf()
+	if leaf == nil {
+		return ""
+	}
+
+	if leaf.Subject.CommonName != "" {
+		return leaf.Subject.CommonName
+	}
+	if len(leaf.DNSNames) > 0 {
+		return leaf.DNSNames[0]
+	}
+
+	return ""
+}
+
+// ChainPEM returns the PEM encoding of the chain.
+func (b *Bundle) ChainPEM() string {
+	var buf bytes.Buffer
+	for _, cert := range b.Chain {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+
+	return buf.String()
+}
+
+// KeyPEM returns the PEM encoding of the private key in PKCS #8 form.
+func (b *Bundle) KeyPEM() (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(b.Key)
+	if err != nil {
+		return "", fmt.Errorf("encoding private key: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// CreateRequest returns a request which uploads the bundle under the given
+// name.  The common name is filled in from the leaf certificate.
+func (b *Bundle) CreateRequest(name string) (*CreateRequest, error) {
+	key, err := b.KeyPEM()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateRequest{
+		Name:  name,
+		CN:    b.CommonName(),
+		Chain: b.ChainPEM(),
+		PKey:  key,
+	}, nil
+}
+
+// ParseBundle parses a PEM-encoded certificate chain, starting with the leaf
+// certificate, and the PEM-encoded private key of the leaf certificate.  The
+// key may be in PKCS #1, SEC 1 or PKCS #8 form.  Use Validate to check that
+// the bundle is consistent.
+func ParseBundle(chainPEM, keyPEM []byte) (*Bundle, error) {
+	chain, err := parseChain(chainPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parseKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bundle{Chain: chain, Key: key}, nil
+}
+
+// LoadBundle reads and parses the PEM-encoded certificate chain and private
+// key from the files at the given paths.  Both may be the same file.
+func LoadBundle(chainPath, keyPath string) (*Bundle, error) {
+	chainPEM, err := os.ReadFile(chainPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificate chain: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+
+	return ParseBundle(chainPEM, keyPEM)
+}
+
+// ParsePKCS12 parses a PKCS #12 archive, e.g. a .pfx or .p12 file, which
+// contains a certificate chain and its private key.  The certificates of the
+// authorities are ordered so that each is followed by its issuer, if present.
+func ParsePKCS12(data []byte, password string) (*Bundle, error) {
+	key, leaf, cas, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("decoding PKCS #12 archive: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return &Bundle{
+		Chain: orderChain(leaf, cas),
+		Key:   signer,
+	}, nil
+}
+
+// LoadPKCS12 reads and parses the PKCS #12 archive at the given path.
+func LoadPKCS12(path, password string) (*Bundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading PKCS #12 archive: %w", err)
+	}
+
+	return ParsePKCS12(data, password)
+}
+
+// parseChain parses all certificates of a PEM-encoded chain in order.
+func parseChain(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+
+	for i := 0; ; i++ {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("parsing certificate chain: block %d: unexpected PEM type '%s'", i, block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate chain: block %d: %w", i, err)
+		}
+
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, errors.New("parsing certificate chain: no PEM-encoded certificates found")
+	}
+
+	return chain, nil
+}
+
+// parseKey parses the first PEM-encoded private key.  Other blocks, e.g.
+// certificates, are skipped.
+func parseKey(data []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("parsing private key: no PEM-encoded private key found")
+		}
+
+		var (
+			key any
+			err error
+		)
+
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, errors.New("parsing private key: encrypted private keys are not supported")
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing private key: %w", err)
+		}
+
+		switch key := key.(type) {
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+			return key.(crypto.Signer), nil
+		default:
+			return nil, fmt.Errorf("parsing private key: unsupported key type %T", key)
+		}
+	}
+}
+
+// orderChain orders the certificates of the authorities so that each
+// certificate is followed by its issuer.  Certificates which are not part of
+// the path of the leaf are appended in their original order.
+func orderChain(leaf *x509.Certificate, cas []*x509.Certificate) []*x509.Certificate {
+	chain := []*x509.Certificate{leaf}
+	used := make([]bool, len(cas))
+
+	for cur := leaf; !isSelfSigned(cur); {
+		next := -1
+		for i, ca := range cas {
+			if !used[i] && cur.CheckSignatureFrom(ca) == nil {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		used[next] = true
+		cur = cas[next]
+		chain = append(chain, cur)
+	}
+
+	for i, ca := range cas {
+		if !used[i] {
+			chain = append(chain, ca)
+		}
+	}
+
+	return chain
+}
+
+// isSelfSigned reports whether the certificate is signed by its own key.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
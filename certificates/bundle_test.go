@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package certificates_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/certificates"
+)
+
+type issued struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// issue creates a certificate signed by the parent, or a self-signed one if
+// the parent is nil.
+func issue(t *testing.T, parent *issued, template *x509.Certificate) *issued {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(24 * time.Hour)
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &issued{cert: cert, key: key}
+}
+
+func authority(name string) *x509.Certificate {
+	return &x509.Certificate{
+		Subject:               pkix.Name{CommonName: name},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+}
+
+func encode(t *testing.T, certs ...*issued) []byte {
+	var out []byte
+	for _, c := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})...)
+	}
+	return out
+}
+
+func encodeKey(t *testing.T, c *issued) []byte {
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+type chain struct {
+	root, intermediate, leaf *issued
+}
+
+func newChain(t *testing.T) chain {
+	root := issue(t, nil, authority("Root"))
+	intermediate := issue(t, root, authority("Intermediate"))
+	leaf := issue(t, intermediate, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com", "*.example.com"},
+	})
+
+	return chain{root: root, intermediate: intermediate, leaf: leaf}
+}
+
+func TestBundleValidate(t *testing.T) {
+	c := newChain(t)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(c.root.cert)
+
+	bundle, err := certificates.ParseBundle(encode(t, c.leaf, c.intermediate), encodeKey(t, c.leaf))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bundle.Validate(certificates.WithDomains("www.example.com"), certificates.WithRoots(roots)); err != nil {
+		t.Errorf("expected the bundle to be valid, got %v", err)
+	}
+
+	req, err := bundle.CreateRequest("example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.CN != "example.com" || req.Validate() != nil {
+		t.Errorf("expected a valid request for example.com, got %+v", req)
+	}
+
+	tests := []struct {
+		name  string
+		chain []byte
+		key   []byte
+		opts  []certificates.ValidateOption
+		want  error
+	}{
+		{
+			name:  "key mismatch",
+			chain: encode(t, c.leaf, c.intermediate),
+			key:   encodeKey(t, c.intermediate),
+			want:  certificates.ErrKeyMismatch,
+		},
+		{
+			name:  "out of order",
+			chain: encode(t, c.leaf, c.root, c.intermediate),
+			key:   encodeKey(t, c.leaf),
+			want:  certificates.ErrChainOrder,
+		},
+		{
+			name:  "expired",
+			chain: encode(t, c.leaf, c.intermediate),
+			key:   encodeKey(t, c.leaf),
+			opts:  []certificates.ValidateOption{certificates.WithValidationTime(time.Now().Add(48 * time.Hour))},
+			want:  certificates.ErrExpired,
+		},
+		{
+			name:  "not yet valid",
+			chain: encode(t, c.leaf, c.intermediate),
+			key:   encodeKey(t, c.leaf),
+			opts:  []certificates.ValidateOption{certificates.WithValidationTime(time.Now().Add(-48 * time.Hour))},
+			want:  certificates.ErrNotYetValid,
+		},
+		{
+			name:  "name mismatch",
+			chain: encode(t, c.leaf, c.intermediate),
+			key:   encodeKey(t, c.leaf),
+			opts:  []certificates.ValidateOption{certificates.WithDomains("a.b.example.com")},
+			want:  certificates.ErrNameMismatch,
+		},
+		{
+			name:  "missing intermediate",
+			chain: encode(t, c.leaf),
+			key:   encodeKey(t, c.leaf),
+			opts:  []certificates.ValidateOption{certificates.WithRoots(roots)},
+			want:  certificates.ErrChainInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, err := certificates.ParseBundle(tt.chain, tt.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := bundle.Validate(tt.opts...); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestParsePKCS12(t *testing.T) {
+	c := newChain(t)
+
+	data, err := pkcs12.Modern.Encode(c.leaf.key, c.leaf.cert, []*x509.Certificate{c.root.cert, c.intermediate.cert}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := certificates.ParsePKCS12(data, "wrong"); err == nil {
+		t.Error("expected an error for a wrong password")
+	}
+
+	bundle, err := certificates.ParsePKCS12(data, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bundle.Chain) != 3 || bundle.Chain[1].Subject.CommonName != "Intermediate" || bundle.Chain[2].Subject.CommonName != "Root" {
+		t.Errorf("expected the chain to be ordered by issuer, got %d certificates", len(bundle.Chain))
+	}
+	if err := bundle.Validate(); err != nil {
+		t.Errorf("expected the bundle to be valid, got %v", err)
+	}
+}
+
+func TestCreateValidates(t *testing.T) {
+	c := newChain(t)
+
+	var uploaded certificates.CreateRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&uploaded)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"certificates":[{"status":"success","uuid":"c1","name":"example"}]}}`))
+	}))
+	defer srv.Close()
+
+	client := kraftcloud.NewCertificatesClient().WithMetro(srv.URL)
+
+	_, err := client.Create(context.Background(), &certificates.CreateRequest{
+		Name:  "example",
+		CN:    "example.org",
+		Chain: string(encode(t, c.leaf, c.intermediate)),
+		PKey:  string(encodeKey(t, c.leaf)),
+	})
+	if !errors.Is(err, certificates.ErrNameMismatch) || uploaded.Name != "" {
+		t.Fatalf("expected the request to be rejected before upload, got %v", err)
+	}
+
+	if _, err := client.Create(context.Background(), &certificates.CreateRequest{
+		Name:  "example",
+		Chain: string(encode(t, c.leaf, c.intermediate)),
+		PKey:  string(encodeKey(t, c.leaf)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if uploaded.CN != "example.com" {
+		t.Errorf("expected the common name to be filled in, got %q", uploaded.CN)
+	}
+}
@@ -7,3 +7,17 @@ package certificates
 
 // Endpoint is the public path for the certificates service.
 const Endpoint = "/certificates"
+
+// State is the state of a certificate.
+type State string
+
+const (
+	// The certificate is being issued, e.g. its domain is being validated.
+	StatePending State = "pending"
+
+	// The certificate is valid and can be served.
+	StateValid State = "valid"
+
+	// Issuing the certificate failed.
+	StateError State = "error"
+)
@@ -15,9 +15,28 @@ import (
 	kcclient "sdk.kraft.cloud/client"
 )
 
-// Create implements InstancesService.
+// Create implements CertificatesService.
 func (c *client) Create(ctx context.Context, req *CreateRequest) (*kcclient.ServiceResponse[CreateResponseItem], error) {
-	body, err := json.Marshal(req)
+	bundle, err := ParseBundle([]byte(req.Chain), []byte(req.PKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	creq := *req
+	if creq.CN == "" {
+		creq.CN = bundle.CommonName()
+	}
+
+	var vopts []ValidateOption
+	if creq.CN != "" {
+		vopts = append(vopts, WithDomains(creq.CN))
+	}
+
+	if err := bundle.Validate(vopts...); err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+
+	body, err := json.Marshal(creq)
 	if err != nil {
 		return nil, fmt.Errorf("error marshalling request body: %w", err)
 	}
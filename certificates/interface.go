@@ -14,7 +14,9 @@ import (
 type CertificatesService interface {
 	kcclient.ServiceClient[CertificatesService]
 
-	// Create creates a new certificate.
+	// Create creates a new certificate.  The chain and private key are parsed
+	// and checked with Bundle.Validate before they are uploaded, and an empty
+	// common name is filled in from the leaf certificate.
 	//
 	// See: https://docs.kraft.cloud/api/v1/certificates/#uploading-an-existing-certificate
 	Create(ctx context.Context, req *CreateRequest) (*kcclient.ServiceResponse[CreateResponseItem], error)
@@ -22,12 +24,12 @@ type CertificatesService interface {
 	// Get returns the current status and the properties of one or more certificate(s).
 	//
 	// See: https://docs.kraft.cloud/api/v1/certificates/#getting-the-status-of-a-certificate
-	Get(ctx context.Context, uuids ...string) (*kcclient.ServiceResponse[GetResponseItem], error)
+	Get(ctx context.Context, uuids ...kcclient.Ref) (*kcclient.ServiceResponse[GetResponseItem], error)
 
 	// Delete deletes one or more certificate(s).
 	//
 	// See: https://docs.kraft.cloud/api/v1/certificates/#deleting-a-certificate
-	Delete(ctx context.Context, uuids ...string) (*kcclient.ServiceResponse[DeleteResponseItem], error)
+	Delete(ctx context.Context, uuids ...kcclient.Ref) (*kcclient.ServiceResponse[DeleteResponseItem], error)
 
 	// List all existing certificates.
 	//
@@ -25,29 +25,39 @@ type CreateResponseItem struct {
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i CreateResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
 // GetResponseItem is a data item from a response to a GET /certificates request.
 // https://docs.kraft.cloud/api/v1/certificates/#getting-the-status-of-a-certificate
 type GetResponseItem struct {
 	Status        string                    `json:"status"`
 	UUID          string                    `json:"uuid"`
 	Name          string                    `json:"name"`
-	CreatedAt     string                    `json:"created_at"`
+	CreatedAt     kcclient.Timestamp        `json:"created_at"`
 	CommonName    string                    `json:"common_name"`
-	State         string                    `json:"state"`
+	State         State                     `json:"state"`
 	Validation    *GetResponseValidation    `json:"validation"`
 	Subject       string                    `json:"subject"`
 	Issuer        string                    `json:"issuer"`
 	SerialNumber  string                    `json:"serial_number"`
-	NotBefore     string                    `json:"not_before"`
-	NotAfter      string                    `json:"not_after"`
+	NotBefore     kcclient.Timestamp        `json:"not_before"`
+	NotAfter      kcclient.Timestamp        `json:"not_after"`
 	ServiceGroups []GetResponseServiceGroup `json:"service_groups"`
 
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i GetResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
 type GetResponseValidation struct {
-	Attempt int    `json:"attempt"`
-	Next    string `json:"next"`
+	Attempt int                `json:"attempt"`
+	Next    kcclient.Timestamp `json:"next"`
 }
 
 type GetResponseServiceGroup struct {
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package rotation monitors certificates on KraftCloud for approaching expiry,
+// stalled validation and errors, and replaces certificates in use by service
+// groups before they expire without interrupting the domains they secure.
+package rotation
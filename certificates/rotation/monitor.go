@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package rotation
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"sdk.kraft.cloud/certificates"
+	kcclient "sdk.kraft.cloud/client"
+)
+
+// Condition is a reason for which a certificate requires attention.
+type Condition string
+
+const (
+	// The certificate has failed to be issued.
+	ConditionError Condition = "error"
+
+	// The certificate has expired.
+	ConditionExpired Condition = "expired"
+
+	// The validation of the domain of a pending certificate does not succeed.
+	ConditionStuck Condition = "stuck"
+
+	// The certificate expires within the expiry window.
+	ConditionExpiring Condition = "expiring"
+)
+
+// Finding is a certificate which requires attention.
+type Finding struct {
+	// Certificate identifies the certificate.
+	Certificate kcclient.Identity `json:"certificate"`
+
+	// CommonName is the name for which the certificate is issued.
+	CommonName string `json:"common_name"`
+
+	// Condition is the reason for which the certificate requires attention.
+	Condition Condition `json:"condition"`
+
+	// State is the state of the certificate.
+	State certificates.State `json:"state"`
+
+	// NotAfter is the expiry of the certificate, if known.
+	NotAfter time.Time `json:"not_after,omitzero"`
+
+	// Attempts is the number of attempts to validate a pending certificate.
+	Attempts int `json:"attempts,omitempty"`
+
+	// NextAttempt is the time of the next validation attempt, if any.
+	NextAttempt time.Time `json:"next_attempt,omitzero"`
+
+	// ServiceGroups are the service groups which use the certificate.
+	ServiceGroups []kcclient.Identity `json:"service_groups,omitempty"`
+}
+
+// ServiceGroupReport lists the findings of the certificates used by a service
+// group.
+type ServiceGroupReport struct {
+	// ServiceGroup identifies the service group.
+	ServiceGroup kcclient.Identity `json:"service_group"`
+
+	// Findings are the certificates used by the service group which require
+	// attention.
+	Findings []Finding `json:"findings"`
+}
+
+// Report is the outcome of a check of all certificates.
+type Report struct {
+	// Time is the time of the check.
+	Time time.Time `json:"time"`
+
+	// Certificates is the number of checked certificates.
+	Certificates int `json:"certificates"`
+
+	// Findings are all certificates which require attention, most urgent
+	// first.
+	Findings []Finding `json:"findings"`
+
+	// ServiceGroups groups the findings by the service groups which use the
+	// certificates, ordered by name.  Certificates which are not in use by any
+	// service group only appear in Findings.
+	ServiceGroups []ServiceGroupReport `json:"service_groups"`
+}
+
+// Monitor reports certificates which approach their expiry, are stuck in
+// validation or have failed.
+type Monitor struct {
+	certificates  certificates.CertificatesService
+	window        time.Duration
+	stuckAttempts int
+	stuckAfter    time.Duration
+	now           func() time.Time
+}
+
+// NewMonitor instantiates a new Monitor which uses the given client.
+func NewMonitor(certs certificates.CertificatesService, opts ...MonitorOption) *Monitor {
+	m := &Monitor{
+		certificates:  certs,
+		window:        DefaultExpiryWindow,
+		stuckAttempts: DefaultStuckAttempts,
+		stuckAfter:    DefaultStuckAfter,
+		now:           time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Check fetches all certificates and reports those which require attention.
+// If only the details of some certificates could not be fetched, the report of
+// the others is returned alongside the error.
+func (m *Monitor) Check(ctx context.Context) (*Report, error) {
+	list, err := m.certificates.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing certificates: %w", err)
+	}
+
+	entries, err := list.AllOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("listing certificates: %w", err)
+	}
+
+	report := &Report{Time: m.now()}
+	if len(entries) == 0 {
+		return report, nil
+	}
+
+	ids := make([]kcclient.Ref, len(entries))
+	for i, entry := range entries {
+		ids[i] = kcclient.ByUUID(entry.UUID)
+	}
+
+	resp, err := kcclient.Batch(ctx, ids, m.certificates.Get)
+	if resp == nil {
+		return nil, fmt.Errorf("getting certificates: %w", err)
+	}
+
+	all, allErr := resp.AllOrErr()
+	for _, cert := range all {
+		if cert.ErrorAttributes().Error != nil {
+			continue
+		}
+
+		report.Certificates++
+
+		if finding, ok := m.classify(cert, report.Time); ok {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+
+	slices.SortStableFunc(report.Findings, compareFindings)
+	report.ServiceGroups = groupFindings(report.Findings)
+
+	if err := errors.Join(err, allErr); err != nil {
+		return report, fmt.Errorf("getting certificates: %w", err)
+	}
+
+	return report, nil
+}
+
+// classify returns the finding of the certificate, if it requires attention.
+func (m *Monitor) classify(cert certificates.GetResponseItem, now time.Time) (Finding, bool) {
+	finding := Finding{
+		Certificate: kcclient.Identity{UUID: cert.UUID, Name: cert.Name},
+		CommonName:  cert.CommonName,
+		State:       cert.State,
+		NotAfter:    cert.NotAfter.Time,
+	}
+
+	if v := cert.Validation; v != nil {
+		finding.Attempts = v.Attempt
+		finding.NextAttempt = v.Next.Time
+	}
+
+	for _, group := range cert.ServiceGroups {
+		finding.ServiceGroups = append(finding.ServiceGroups, kcclient.Identity{UUID: group.UUID, Name: group.Name})
+	}
+
+	switch cert.State {
+	case certificates.StateError:
+		finding.Condition = ConditionError
+
+	case certificates.StatePending:
+		created := cert.CreatedAt.Time
+		if finding.Attempts < m.stuckAttempts && (created.IsZero() || now.Sub(created) < m.stuckAfter) {
+			return finding, false
+		}
+		finding.Condition = ConditionStuck
+
+	default:
+		switch {
+		case finding.NotAfter.IsZero():
+			return finding, false
+		case !now.Before(finding.NotAfter):
+			finding.Condition = ConditionExpired
+		case finding.NotAfter.Sub(now) < m.window:
+			finding.Condition = ConditionExpiring
+		default:
+			return finding, false
+		}
+	}
+
+	return finding, true
+}
+
+// urgency orders the conditions from the most to the least urgent.
+var urgency = map[Condition]int{
+	ConditionError:    0,
+	ConditionExpired:  1,
+	ConditionStuck:    2,
+	ConditionExpiring: 3,
+}
+
+// compareFindings orders findings by the urgency of their condition and then
+// by their expiry.
+func compareFindings(a, b Finding) int {
+	if c := cmp.Compare(urgency[a.Condition], urgency[b.Condition]); c != 0 {
+		return c
+	}
+
+	return a.NotAfter.Compare(b.NotAfter)
+}
+
+// groupFindings groups the findings by the service groups which use the
+// certificates.
+func groupFindings(findings []Finding) []ServiceGroupReport {
+	var groups []ServiceGroupReport
+	index := make(map[string]int)
+
+	for _, finding := range findings {
+		for _, group := range finding.ServiceGroups {
+			key := group.UUID + "/" + group.Name
+
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, ServiceGroupReport{ServiceGroup: group})
+			}
+
+			groups[i].Findings = append(groups[i].Findings, finding)
+		}
+	}
+
+	slices.SortFunc(groups, func(a, b ServiceGroupReport) int {
+		return cmp.Or(
+			cmp.Compare(a.ServiceGroup.Name, b.ServiceGroup.Name),
+			cmp.Compare(a.ServiceGroup.UUID, b.ServiceGroup.UUID),
+		)
+	})
+
+	return groups
+}
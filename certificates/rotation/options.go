@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package rotation
+
+import "time"
+
+const (
+	// DefaultExpiryWindow is the default time before the expiry of a
+	// certificate from which it is reported as expiring.
+	DefaultExpiryWindow = 30 * 24 * time.Hour
+
+	// DefaultStuckAttempts is the default number of validation attempts after
+	// which a pending certificate is reported as stuck.
+	DefaultStuckAttempts = 5
+
+	// DefaultStuckAfter is the default time after its creation at which a
+	// pending certificate is reported as stuck.
+	DefaultStuckAfter = time.Hour
+
+	// DefaultInterval is the default interval at which the Scheduler checks
+	// the certificates.
+	DefaultInterval = time.Hour
+
+	// DefaultPollInterval is the default interval at which the Scheduler polls
+	// the state of a replacement certificate until it is valid.
+	DefaultPollInterval = 5 * time.Second
+
+	// DefaultValidTimeout is the default time after which the Scheduler gives
+	// up waiting for a replacement certificate to become valid.
+	DefaultValidTimeout = 10 * time.Minute
+)
+
+// MonitorOption is an option function used during initialization of a
+// Monitor.
+type MonitorOption func(*Monitor)
+
+// WithExpiryWindow sets the time before the expiry of a certificate from which
+// it is reported as expiring.
+func WithExpiryWindow(window time.Duration) MonitorOption {
+	return func(m *Monitor) {
+		m.window = window
+	}
+}
+
+// WithStuckAttempts sets the number of validation attempts after which a
+// pending certificate is reported as stuck.
+func WithStuckAttempts(n int) MonitorOption {
+	return func(m *Monitor) {
+		m.stuckAttempts = n
+	}
+}
+
+// WithStuckAfter sets the time after its creation at which a pending
+// certificate is reported as stuck.
+func WithStuckAfter(after time.Duration) MonitorOption {
+	return func(m *Monitor) {
+		m.stuckAfter = after
+	}
+}
+
+// WithClock sets the function which returns the current time.
+func WithClock(now func() time.Time) MonitorOption {
+	return func(m *Monitor) {
+		m.now = now
+	}
+}
+
+// SchedulerOption is an option function used during initialization of a
+// Scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithInterval sets the interval at which Run checks the certificates.
+// Defaults to DefaultInterval if unset or not positive.
+func WithInterval(interval time.Duration) SchedulerOption {
+	return func(s *Scheduler) {
+		s.interval = interval
+	}
+}
+
+// WithPollInterval sets the interval at which the state of a replacement
+// certificate is polled until it is valid.  Defaults to DefaultPollInterval if
+// unset or not positive.
+func WithPollInterval(interval time.Duration) SchedulerOption {
+	return func(s *Scheduler) {
+		s.pollInterval = interval
+	}
+}
+
+// WithValidTimeout sets the time after which a rotation gives up waiting for
+// its replacement certificate to become valid.  The replacement is then
+// deleted without being bound to any domain.  Defaults to DefaultValidTimeout
+// if unset or not positive.
+func WithValidTimeout(timeout time.Duration) SchedulerOption {
+	return func(s *Scheduler) {
+		s.validTimeout = timeout
+	}
+}
+
+// WithConditions sets the conditions of certificates which are rotated.  By
+// default, expiring and expired certificates are rotated.
+func WithConditions(conditions ...Condition) SchedulerOption {
+	return func(s *Scheduler) {
+		s.conditions = conditions
+	}
+}
+
+// WithDeleteReplaced deletes certificates once all of their domains have been
+// rebound to the replacement.
+func WithDeleteReplaced() SchedulerOption {
+	return func(s *Scheduler) {
+		s.deleteReplaced = true
+	}
+}
+
+// WithRotationHandler sets a function which is called with the outcome of
+// every check of Run, i.e. the report, the attempted rotations and the error,
+// if any.
+func WithRotationHandler(fn func(*Report, []Rotation, error)) SchedulerOption {
+	return func(s *Scheduler) {
+		s.handler = fn
+	}
+}
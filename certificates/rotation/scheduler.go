@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"sdk.kraft.cloud/certificates"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/domains"
+)
+
+// cleanupTimeout is the time allowed for deleting the replacement of a failed
+// rotation.
+const cleanupTimeout = 30 * time.Second
+
+// RenewFunc returns the request which uploads the replacement of the
+// certificate of the finding, e.g. built with certificates.Bundle's
+// CreateRequest from a renewed certificate.  If it returns a nil request and
+// no error, the certificate is not rotated.
+type RenewFunc func(ctx context.Context, finding Finding) (*certificates.CreateRequest, error)
+
+// Rotation is the outcome of replacing a certificate.
+type Rotation struct {
+	// Finding is the finding of the replaced certificate.
+	Finding Finding `json:"finding"`
+
+	// Replacement identifies the uploaded replacement, if any.
+	Replacement kcclient.Identity `json:"replacement"`
+
+	// ReplacementDeleted is true if the replacement has been deleted again,
+	// because the rotation failed before any domain was rebound to it.  A
+	// failed rotation whose replacement was neither rebound nor deleted leaves
+	// the replacement orphaned.
+	ReplacementDeleted bool `json:"replacement_deleted,omitempty"`
+
+	// Domains are the domains which have been rebound to the replacement.
+	Domains []string `json:"domains,omitempty"`
+
+	// Deleted is true if the replaced certificate has been deleted.
+	Deleted bool `json:"deleted,omitempty"`
+
+	// Err is set if the certificate could not be replaced on all of its
+	// domains.
+	Err error `json:"-"`
+}
+
+// Scheduler periodically checks all certificates with a Monitor and replaces
+// those in use by service groups which require it with certificates obtained
+// from a RenewFunc.
+//
+// A replacement is only bound once it is valid, and each domain is rebound in
+// a single operation with domains.Manager's Rebind, so that domains are served
+// with either certificate at any time.
+type Scheduler struct {
+	monitor *Monitor
+	domains *domains.Manager
+	renew   RenewFunc
+
+	interval       time.Duration
+	pollInterval   time.Duration
+	validTimeout   time.Duration
+	conditions     []Condition
+	deleteReplaced bool
+	handler        func(*Report, []Rotation, error)
+}
+
+// NewScheduler instantiates a new Scheduler which checks the certificates with
+// the monitor, obtains replacements from the renew function and rebinds
+// domains with the manager.
+func NewScheduler(monitor *Monitor, manager *domains.Manager, renew RenewFunc, opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		monitor:      monitor,
+		domains:      manager,
+		renew:        renew,
+		interval:     DefaultInterval,
+		pollInterval: DefaultPollInterval,
+		validTimeout: DefaultValidTimeout,
+		conditions:   []Condition{ConditionExpiring, ConditionExpired},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.interval <= 0 {
+		s.interval = DefaultInterval
+	}
+	if s.pollInterval <= 0 {
+		s.pollInterval = DefaultPollInterval
+	}
+	if s.validTimeout <= 0 {
+		s.validTimeout = DefaultValidTimeout
+	}
+
+	return s
+}
+
+// Check checks all certificates once and rotates those in use by service
+// groups whose condition is one of the configured conditions.  Certificates
+// which are not in use are reported but not rotated.
+func (s *Scheduler) Check(ctx context.Context) (*Report, []Rotation, error) {
+	report, err := s.monitor.Check(ctx)
+	if report == nil {
+		return nil, nil, err
+	}
+
+	var (
+		rotations []Rotation
+		errs      = []error{err}
+	)
+
+	for _, finding := range report.Findings {
+		if len(finding.ServiceGroups) == 0 || !slices.Contains(s.conditions, finding.Condition) {
+			continue
+		}
+
+		rotation := s.Rotate(ctx, finding)
+		if rotation.Err != nil {
+			errs = append(errs, rotation.Err)
+		}
+		if rotation.Replacement.UUID != "" || rotation.Err != nil {
+			rotations = append(rotations, rotation)
+		}
+	}
+
+	return report, rotations, errors.Join(errs...)
+}
+
+// Rotate replaces the certificate of the finding: it uploads the replacement
+// obtained from the renew function, waits until it is valid and rebinds all
+// domains secured by the certificate to it.  If the rotation fails before any
+// domain was rebound, the replacement is deleted again.
+func (s *Scheduler) Rotate(ctx context.Context, finding Finding) Rotation {
+	rotation := s.rotate(ctx, finding)
+
+	if rotation.Err != nil && rotation.Replacement.UUID != "" && len(rotation.Domains) == 0 {
+		if err := s.deleteReplacement(ctx, rotation.Replacement.UUID); err != nil {
+			rotation.Err = errors.Join(rotation.Err, fmt.Errorf("deleting orphaned replacement '%s': %w", rotation.Replacement.Name, err))
+		} else {
+			rotation.ReplacementDeleted = true
+		}
+	}
+
+	return rotation
+}
+
+// rotate performs the steps of Rotate without cleaning up after failures.
+func (s *Scheduler) rotate(ctx context.Context, finding Finding) Rotation {
+	rotation := Rotation{Finding: finding}
+	name := finding.Certificate.Name
+
+	req, err := s.renew(ctx, finding)
+	if err != nil {
+		rotation.Err = fmt.Errorf("renewing certificate '%s': %w", name, err)
+		return rotation
+	}
+	if req == nil {
+		return rotation
+	}
+
+	resp, err := s.monitor.certificates.Create(ctx, req)
+	if err == nil {
+		var created *certificates.CreateResponseItem
+		if created, err = resp.FirstOrErr(); err == nil {
+			rotation.Replacement = kcclient.Identity{UUID: created.UUID, Name: created.Name}
+		}
+	}
+	if err != nil {
+		rotation.Err = fmt.Errorf("uploading replacement of certificate '%s': %w", name, err)
+		return rotation
+	}
+
+	replacement := kcclient.ByUUID(rotation.Replacement.UUID)
+
+	if err := s.waitValid(ctx, replacement); err != nil {
+		rotation.Err = fmt.Errorf("waiting for replacement of certificate '%s': %w", name, err)
+		return rotation
+	}
+
+	list, err := s.domains.List(ctx)
+	if list == nil && err != nil {
+		rotation.Err = fmt.Errorf("listing domains of certificate '%s': %w", name, err)
+		return rotation
+	}
+
+	var errs []error
+	for _, domain := range list {
+		if domain.Certificate == nil || domain.Certificate.UUID != finding.Certificate.UUID {
+			continue
+		}
+
+		if _, err := s.domains.Rebind(ctx, domain.FQDN, replacement); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		rotation.Domains = append(rotation.Domains, domain.FQDN)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		rotation.Err = fmt.Errorf("rotating certificate '%s': %w", name, err)
+		return rotation
+	}
+
+	if s.deleteReplaced {
+		resp, err := s.monitor.certificates.Delete(ctx, kcclient.ByUUID(finding.Certificate.UUID))
+		if err == nil {
+			_, err = resp.FirstOrErr()
+		}
+		if err != nil {
+			rotation.Err = fmt.Errorf("deleting replaced certificate '%s': %w", name, err)
+			return rotation
+		}
+
+		rotation.Deleted = true
+	}
+
+	return rotation
+}
+
+// Run calls Check at the configured interval until the context is done.
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		report, rotations, err := s.Check(ctx)
+		if s.handler != nil {
+			s.handler(report, rotations, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// deleteReplacement deletes the replacement certificate with the given UUID.
+// It is not aborted by the cancellation of ctx, which may be the reason why
+// the rotation failed, but gives up after cleanupTimeout.
+func (s *Scheduler) deleteReplacement(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	resp, err := s.monitor.certificates.Delete(ctx, kcclient.ByUUID(uuid))
+	if err != nil {
+		return err
+	}
+
+	_, err = resp.FirstOrErr()
+	return err
+}
+
+// waitValid polls the referenced certificate until it is valid, for at most
+// the configured timeout.
+func (s *Scheduler) waitValid(ctx context.Context, id kcclient.Ref) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, s.validTimeout, fmt.Errorf("not valid after %s", s.validTimeout))
+	defer cancel()
+
+	for {
+		resp, err := s.monitor.certificates.Get(ctx, id)
+		if err != nil && ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		cert, err := resp.FirstOrErr()
+		if err != nil {
+			return err
+		}
+
+		switch cert.State {
+		case certificates.StateValid:
+			return nil
+		case certificates.StateError:
+			return fmt.Errorf("certificate '%s' is %s", cert.Name, cert.State)
+		}
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
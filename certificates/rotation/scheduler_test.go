@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package rotation_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/certificates"
+	"sdk.kraft.cloud/certificates/rotation"
+	"sdk.kraft.cloud/domains"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+var now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeCertificates serves certificates in various states, of which the one
+// securing the domain of the service group "web" is bound to it.
+type fakeCertificates struct {
+	group   *fakeapi.ServiceGroup
+	deleted map[string]bool
+	created int
+
+	// pending keeps replacements pending forever.
+	pending bool
+}
+
+// certificate describes the certificate with the given UUID.
+func (f *fakeCertificates) certificate(uuid string) string {
+	state, notAfter, created, attempt, group := "valid", now.Add(300*24*time.Hour), now.Add(-24*time.Hour), 0, ""
+
+	switch uuid {
+	case "old":
+		notAfter = now.Add(48 * time.Hour)
+	case "expired":
+		notAfter = now.Add(-time.Hour)
+	case "pending":
+		state, attempt, group = "pending", 7, `{"uuid":"g2","name":"api"}`
+	case "failed":
+		state, group = "error", `{"uuid":"g2","name":"api"}`
+	case "new1":
+		if f.pending {
+			state = "pending"
+		}
+	}
+
+	if uuid == f.group.Domains[0].Certificate {
+		group = `{"uuid":"g1","name":"web"}`
+	}
+
+	return fmt.Sprintf(`{"status":"success","uuid":%q,"name":%[1]q,"common_name":"example.com","state":%q,`+
+		`"created_at":%q,"not_after":%q,"validation":{"attempt":%d},"service_groups":[%s]}`,
+		uuid, state, created.Format(time.RFC3339), notAfter.Format(time.RFC3339), attempt, group)
+}
+
+// serve registers the certificate endpoints with the fake API.
+func (f *fakeCertificates) serve(api *fakeapi.Server) {
+	api.Handle(http.MethodGet, "/certificates", "certificates", func(r *fakeapi.Request) []string {
+		var entries []string
+		if len(r.Items) == 0 {
+			for _, uuid := range []string{"old", "expired", "pending", "failed", "fresh", "new1"} {
+				if !f.deleted[uuid] && (uuid != "new1" || f.created > 0) {
+					entries = append(entries, fmt.Sprintf(`{"uuid":%q,"name":%[1]q}`, uuid))
+				}
+			}
+			return entries
+		}
+
+		for i := range r.Items {
+			entries = append(entries, f.certificate(r.String(i, "uuid")))
+		}
+		return entries
+	})
+
+	api.Handle(http.MethodPost, "/certificates", "certificates", func(r *fakeapi.Request) []string {
+		f.created++
+		return []string{fmt.Sprintf(`{"status":"success","uuid":"new%d","name":"new%[1]d"}`, f.created)}
+	})
+
+	api.Handle(http.MethodDelete, "/certificates", "certificates", func(r *fakeapi.Request) []string {
+		uuid := r.String(0, "uuid")
+		f.deleted[uuid] = true
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q}`, uuid)}
+	})
+}
+
+// renew issues a self-signed certificate for the common name of the finding.
+func renew(ctx context.Context, finding rotation.Finding) (*certificates.CreateRequest, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: finding.CommonName},
+		DNSNames:     []string{finding.CommonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &certificates.Bundle{Chain: []*x509.Certificate{cert}, Key: key}
+	return bundle.CreateRequest("")
+}
+
+func TestScheduler(t *testing.T) {
+	api := fakeapi.New(t)
+
+	group := &fakeapi.ServiceGroup{
+		UUID:    "g1",
+		Name:    "web",
+		Domains: []fakeapi.Domain{{FQDN: "example.com", Certificate: "old"}},
+	}
+	api.ServeServiceGroup(group)
+
+	fake := &fakeCertificates{group: group, deleted: make(map[string]bool)}
+	fake.serve(api)
+
+	certs := kraftcloud.NewCertificatesClient().WithMetro(api.URL)
+	manager := domains.NewManager(kraftcloud.NewServicesClient().WithMetro(api.URL), certs)
+	monitor := rotation.NewMonitor(certs, rotation.WithClock(func() time.Time { return now }))
+	scheduler := rotation.NewScheduler(monitor, manager, renew, rotation.WithDeleteReplaced())
+
+	ctx := context.Background()
+
+	report, err := monitor.Check(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var conditions []string
+	for _, finding := range report.Findings {
+		conditions = append(conditions, finding.Certificate.Name+"="+string(finding.Condition))
+	}
+	if got := strings.Join(conditions, " "); got != "failed=error expired=expired pending=stuck old=expiring" {
+		t.Errorf("expected the findings ordered by urgency, got %s", got)
+	}
+
+	if len(report.ServiceGroups) != 2 || report.ServiceGroups[0].ServiceGroup.Name != "api" || len(report.ServiceGroups[0].Findings) != 2 ||
+		report.ServiceGroups[1].Findings[0].Certificate.UUID != "old" {
+		t.Errorf("expected the findings grouped by service group, got %+v", report.ServiceGroups)
+	}
+
+	_, rotations, err := scheduler.Check(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rotations) != 1 {
+		t.Fatalf("expected only the certificate in use to be rotated, got %+v", rotations)
+	}
+
+	r := rotations[0]
+	if r.Finding.Certificate.UUID != "old" || r.Replacement.UUID != "new1" || len(r.Domains) != 1 || !r.Deleted || group.Domains[0].Certificate != "new1" {
+		t.Errorf("expected the domain to be rebound to the replacement, got %+v", r)
+	}
+
+	if _, rotations, err := scheduler.Check(ctx); err != nil || len(rotations) != 0 {
+		t.Errorf("expected no further rotations, got %+v, %v", rotations, err)
+	}
+}
+
+func TestSchedulerValidTimeout(t *testing.T) {
+	api := fakeapi.New(t)
+
+	group := &fakeapi.ServiceGroup{
+		UUID:    "g1",
+		Name:    "web",
+		Domains: []fakeapi.Domain{{FQDN: "example.com", Certificate: "old"}},
+	}
+	api.ServeServiceGroup(group)
+
+	fake := &fakeCertificates{group: group, deleted: make(map[string]bool), pending: true}
+	fake.serve(api)
+
+	certs := kraftcloud.NewCertificatesClient().WithMetro(api.URL)
+	manager := domains.NewManager(kraftcloud.NewServicesClient().WithMetro(api.URL), certs)
+	monitor := rotation.NewMonitor(certs, rotation.WithClock(func() time.Time { return now }))
+	scheduler := rotation.NewScheduler(monitor, manager, renew,
+		rotation.WithPollInterval(5*time.Millisecond),
+		rotation.WithValidTimeout(50*time.Millisecond),
+	)
+
+	_, rotations, err := scheduler.Check(context.Background())
+	if err == nil || len(rotations) != 1 || rotations[0].Err == nil || !strings.Contains(rotations[0].Err.Error(), "not valid after") {
+		t.Fatalf("expected the rotation to give up on the pending replacement, got %+v, %v", rotations, err)
+	}
+	if group.Domains[0].Certificate != "old" {
+		t.Errorf("expected the domain to keep its certificate, got %s", group.Domains[0].Certificate)
+	}
+	if !rotations[0].ReplacementDeleted || !fake.deleted["new1"] || fake.deleted["old"] {
+		t.Errorf("expected only the orphaned replacement to be deleted, got %+v, deleted %v", rotations[0], fake.deleted)
+	}
+}
+
+func TestSchedulerRunDefaultsInterval(t *testing.T) {
+	api := fakeapi.New(t)
+	certs := kraftcloud.NewCertificatesClient().WithMetro(api.URL)
+
+	// Non-positive intervals fall back to the defaults instead of panicking.
+	scheduler := rotation.NewScheduler(
+		rotation.NewMonitor(certs),
+		domains.NewManager(kraftcloud.NewServicesClient().WithMetro(api.URL), certs),
+		renew,
+		rotation.WithInterval(0),
+		rotation.WithPollInterval(-1),
+		rotation.WithValidTimeout(0),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := scheduler.Run(ctx); err != context.Canceled {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+}
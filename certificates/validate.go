@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package certificates
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrKeyMismatch is returned when the private key does not belong to the
+	// leaf certificate.
+	ErrKeyMismatch = errors.New("private key does not match the leaf certificate")
+
+	// ErrChainOrder is returned when a certificate of the chain is not followed
+	// by its issuer.
+	ErrChainOrder = errors.New("certificate chain is out of order")
+
+	// ErrChainInvalid is returned when the chain contains a certificate which
+	// cannot issue certificates or does not lead to a trusted root.
+	ErrChainInvalid = errors.New("certificate chain is invalid")
+
+	// ErrExpired is returned when a certificate of the chain has expired.
+	ErrExpired = errors.New("certificate has expired")
+
+	// ErrNotYetValid is returned when a certificate of the chain is not valid
+	// yet.
+	ErrNotYetValid = errors.New("certificate is not valid yet")
+
+	// ErrNameMismatch is returned when the leaf certificate does not cover the
+	// requested domain.
+	ErrNameMismatch = errors.New("certificate does not cover the domain")
+)
+
+// ValidateOption is an option function used to configure Validate.
+type ValidateOption func(*validateOptions)
+
+type validateOptions struct {
+	domains []string
+	now     time.Time
+	roots   *x509.CertPool
+}
+
+// WithDomains sets the domains which the leaf certificate must cover.
+func WithDomains(domains ...string) ValidateOption {
+	return func(o *validateOptions) {
+		o.domains = append(o.domains, domains...)
+	}
+}
+
+// WithValidationTime sets the time at which the certificates must be valid.
+// By default, the current time is used.
+func WithValidationTime(t time.Time) ValidateOption {
+	return func(o *validateOptions) {
+		o.now = t
+	}
+}
+
+// WithRoots sets the trusted roots to which the chain must lead.  By default,
+// the chain is not verified against any roots.
+func WithRoots(roots *x509.CertPool) ValidateOption {
+	return func(o *validateOptions) {
+		o.roots = roots
+	}
+}
+
+// Validate checks that the private key matches the leaf certificate, that
+// each certificate of the chain is followed by its issuer, that all
+// certificates are currently valid and that the leaf certificate covers the
+// given domains.  All problems are reported, each wrapping one of the Err*
+// errors of this package.
+func (b *Bundle) Validate(vopts ...ValidateOption) error {
+	opts := validateOptions{
+		now: time.Now(),
+	}
+	for _, opt := range vopts {
+		opt(&opts)
+	}
+
+	if len(b.Chain) == 0 {
+		return fmt.Errorf("%w: no certificates", ErrChainInvalid)
+	}
+
+	var errs []error
+	leaf := b.Chain[0]
+
+	if b.Key == nil {
+		errs = append(errs, fmt.Errorf("%w: no private key", ErrKeyMismatch))
+	} else if pub, ok := leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !pub.Equal(b.Key.Public()) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrKeyMismatch, describe(0, leaf)))
+	}
+
+	for i, cert := range b.Chain {
+		if opts.now.After(cert.NotAfter) {
+			errs = append(errs, fmt.Errorf("%w: %s expired at %s", ErrExpired, describe(i, cert), cert.NotAfter.Format(time.RFC3339)))
+		}
+		if opts.now.Before(cert.NotBefore) {
+			errs = append(errs, fmt.Errorf("%w: %s is valid from %s", ErrNotYetValid, describe(i, cert), cert.NotBefore.Format(time.RFC3339)))
+		}
+
+		if i > 0 && (!cert.BasicConstraintsValid || !cert.IsCA) {
+			errs = append(errs, fmt.Errorf("%w: %s is not a certificate authority", ErrChainInvalid, describe(i, cert)))
+		}
+
+		if i == len(b.Chain)-1 {
+			continue
+		}
+
+		if err := cert.CheckSignatureFrom(b.Chain[i+1]); err != nil {
+			issuer := -1
+			for j, other := range b.Chain {
+				if j != i && j != i+1 && cert.CheckSignatureFrom(other) == nil {
+					issuer = j
+					break
+				}
+			}
+
+			if issuer >= 0 {
+				errs = append(errs, fmt.Errorf("%w: %s is issued by %s, which must follow it", ErrChainOrder, describe(i, cert), describe(issuer, b.Chain[issuer])))
+			} else {
+				errs = append(errs, fmt.Errorf("%w: %s is not issued by %s", ErrChainOrder, describe(i, cert), describe(i+1, b.Chain[i+1])))
+			}
+		}
+	}
+
+	for _, domain := range opts.domains {
+		if !covers(leaf, domain) {
+			errs = append(errs, fmt.Errorf("%w: %s is issued for %s, not '%s'", ErrNameMismatch, describe(0, leaf), names(leaf), domain))
+		}
+	}
+
+	if opts.roots != nil {
+		intermediates := x509.NewCertPool()
+		for _, cert := range b.Chain[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		if _, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         opts.roots,
+			Intermediates: intermediates,
+			CurrentTime:   opts.now,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrChainInvalid, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate parses the chain and private key of the request and checks them
+// with Bundle.Validate, requiring the leaf certificate to cover the common
+// name of the request.
+func (r *CreateRequest) Validate(vopts ...ValidateOption) error {
+	bundle, err := ParseBundle([]byte(r.Chain), []byte(r.PKey))
+	if err != nil {
+		return err
+	}
+
+	if r.CN != "" {
+		vopts = append([]ValidateOption{WithDomains(r.CN)}, vopts...)
+	}
+
+	return bundle.Validate(vopts...)
+}
+
+// describe identifies the certificate at the given position of the chain in
+// error messages.
+func describe(i int, cert *x509.Certificate) string {
+	return fmt.Sprintf("certificate %d (subject '%s')", i, cert.Subject)
+}
+
+// names lists the names for which the certificate is issued.
+func names(cert *x509.Certificate) string {
+	all := cert.DNSNames
+	if len(all) == 0 && cert.Subject.CommonName != "" {
+		all = []string{cert.Subject.CommonName}
+	}
+
+	return "'" + strings.Join(all, "', '") + "'"
+}
+
+// covers reports whether the certificate is valid for the domain.  The common
+// name is only considered if the certificate has no DNS names.
+func covers(cert *x509.Certificate, domain string) bool {
+	domain = strings.TrimSuffix(domain, ".")
+
+	if cert.VerifyHostname(domain) == nil {
+		return true
+	}
+
+	if len(cert.DNSNames) > 0 {
+		return false
+	}
+
+	cn := strings.ToLower(strings.TrimSuffix(cert.Subject.CommonName, "."))
+	domain = strings.ToLower(domain)
+
+	if cn == domain {
+		return true
+	}
+
+	if suffix, ok := strings.CutPrefix(cn, "*."); ok {
+		label, rest, found := strings.Cut(domain, ".")
+		return found && label != "" && rest == suffix
+	}
+
+	return false
+}
@@ -0,0 +1,241 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultBatchChunkSize is the default number of identifiers sent in a
+	// single request by Batch.
+	DefaultBatchChunkSize = 100
+
+	// DefaultBatchConcurrency is the default number of requests performed in
+	// parallel by Batch.
+	DefaultBatchConcurrency = 4
+)
+
+// BatchOption is an option function used to configure Batch.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	chunkSize   int
+	concurrency int
+}
+
+// WithBatchChunkSize sets the number of identifiers sent in a single request.
+func WithBatchChunkSize(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.chunkSize = n
+	}
+}
+
+// WithBatchConcurrency sets the number of requests performed in parallel.
+func WithBatchConcurrency(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.concurrency = n
+	}
+}
+
+// BatchFunc is a service method operating on a variadic list of references,
+// e.g. the Get method of a service.  Methods with further arguments can be
+// adapted with a closure.
+type BatchFunc[T APIResponseDataEntry] func(ctx context.Context, refs ...Ref) (*ServiceResponse[T], error)
+
+// BatchError is returned by Batch if some chunks could not be requested.
+type BatchError struct {
+	// Chunks are the failed chunks in request order.
+	Chunks []BatchChunkError
+}
+
+// BatchChunkError is the error of a single chunk of identifiers.
+type BatchChunkError struct {
+	// IDs are the references of the chunk.
+	IDs []Ref
+
+	// Err is the error returned for the chunk.
+	Err error
+}
+
+// Error implements error.
+func (e *BatchError) Error() string {
+	msgs := make([]string, 0, len(e.Chunks))
+	for _, chunk := range e.Chunks {
+		msgs = append(msgs, fmt.Sprintf("chunk of %d identifier(s) starting at '%s': %s", len(chunk.IDs), chunk.IDs[0], chunk.Err))
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors of the failed chunks.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Chunks))
+	for _, chunk := range e.Chunks {
+		errs = append(errs, chunk.Err)
+	}
+
+	return errs
+}
+
+// IDs returns the references of all failed chunks.
+func (e *BatchError) IDs() []Ref {
+	var ids []Ref
+	for _, chunk := range e.Chunks {
+		ids = append(ids, chunk.IDs...)
+	}
+
+	return ids
+}
+
+// Batch splits the identifiers into chunks, invokes fn for each chunk with
+// bounded concurrency and merges the responses.  The entries of the merged
+// response are in request order, so that the i-th entry corresponds to the i-th
+// reference.  The status of the merged response reflects partial failures of
+// individual chunks, so that AllOrErr combines them.  If the request for a
+// chunk fails altogether, every reference of the chunk gets an error entry in
+// the merged response and a *BatchError is returned alongside it.  The merged
+// response carries no raw body.
+func Batch[T APIResponseDataEntry](ctx context.Context, ids []Ref, fn BatchFunc[T], bopts ...BatchOption) (*ServiceResponse[T], error) {
+	if len(ids) == 0 {
+		return nil, errors.New("requires at least one identifier")
+	}
+
+	opts := batchOptions{
+		chunkSize:   DefaultBatchChunkSize,
+		concurrency: DefaultBatchConcurrency,
+	}
+	for _, opt := range bopts {
+		opt(&opts)
+	}
+	if opts.chunkSize <= 0 {
+		opts.chunkSize = len(ids)
+	}
+	if opts.concurrency <= 0 {
+		opts.concurrency = 1
+	}
+
+	var chunks [][]Ref
+	for start := 0; start < len(ids); start += opts.chunkSize {
+		chunks = append(chunks, ids[start:min(start+opts.chunkSize, len(ids))])
+	}
+
+	type result struct {
+		resp *ServiceResponse[T]
+		err  error
+	}
+
+	results := make([]result, len(chunks))
+	sem := make(chan struct{}, opts.concurrency)
+
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, chunk []Ref) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := fn(ctx, chunk...)
+			results[i] = result{resp: resp, err: err}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	merged := &ServiceResponse[T]{
+		Data: ServiceResponseData[T]{
+			Entries: make([]T, 0, len(ids)),
+		},
+	}
+
+	var (
+		batchErr  BatchError
+		messages  []string
+		succeeded int
+		failed    int
+	)
+
+	for i, res := range results {
+		if res.err == nil && res.resp == nil {
+			res.err = errors.New("response is nil")
+		}
+		if res.err == nil && len(res.resp.Data.Entries) != len(chunks[i]) {
+			res.err = fmt.Errorf("expected %d entries in response, got %d", len(chunks[i]), len(res.resp.Data.Entries))
+		}
+		if res.err != nil {
+			batchErr.Chunks = append(batchErr.Chunks, BatchChunkError{IDs: chunks[i], Err: res.err})
+			for _, ref := range chunks[i] {
+				merged.Data.Entries = append(merged.Data.Entries, errorEntry[T](ref, res.err))
+			}
+			failed++
+			continue
+		}
+
+		merged.Data.Entries = append(merged.Data.Entries, res.resp.Data.Entries...)
+		merged.Errors = append(merged.Errors, res.resp.Errors...)
+
+		switch res.resp.Status {
+		case "error":
+			failed++
+		case "partial_success":
+			succeeded++
+			failed++
+		default:
+			succeeded++
+		}
+
+		if res.resp.Message != "" && res.resp.Status != "success" {
+			messages = append(messages, res.resp.Message)
+		}
+	}
+
+	switch {
+	case failed == 0:
+		merged.Status = "success"
+	case succeeded == 0:
+		merged.Status = "error"
+	default:
+		merged.Status = "partial_success"
+	}
+	if len(batchErr.Chunks) > 0 {
+		messages = append(messages, fmt.Sprintf("%d of %d chunk(s) failed", len(batchErr.Chunks), len(chunks)))
+	}
+	merged.Message = strings.Join(messages, "; ")
+
+	if len(batchErr.Chunks) > 0 {
+		return merged, &batchErr
+	}
+
+	return merged, nil
+}
+
+// errorEntry returns an entry which reports the error of the request for the
+// referenced resource.  The entry is decoded from JSON, since entries of all
+// services share the attributes of their identity and error.
+func errorEntry[T APIResponseDataEntry](ref Ref, err error) T {
+	var entry T
+
+	b, _ := json.Marshal(map[string]any{
+		"status":   "error",
+		"message":  err.Error(),
+		"error":    APIHTTPErrorUnknownError,
+		ref.Attr(): ref.Value(),
+	})
+	_ = json.Unmarshal(b, &entry)
+
+	return entry
+}
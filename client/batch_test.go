@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+type entry struct {
+	ID string `json:"name"`
+
+	kcclient.APIResponseCommon
+}
+
+func TestBatch(t *testing.T) {
+	ids := make([]kcclient.Ref, 10)
+	for i := range ids {
+		ids[i] = kcclient.ByName(strconv.Itoa(i))
+	}
+
+	var inflight, peak atomic.Int32
+	errUnavailable := errors.New("unavailable")
+
+	fn := func(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[entry], error) {
+		n := inflight.Add(1)
+		defer inflight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		// Finish later chunks first to exercise the ordering.
+		first, _ := strconv.Atoi(ids[0].Value())
+		time.Sleep(time.Duration(10-first) * time.Millisecond)
+
+		if ids[0].Value() == "3" {
+			return nil, errUnavailable
+		}
+
+		resp := &kcclient.ServiceResponse[entry]{Status: "success"}
+		for _, id := range ids {
+			e := entry{ID: id.Value()}
+			if id.Value() == "7" {
+				code := kcclient.APIHTTPError(8)
+				e.Message = "not found"
+				e.Error = &code
+				resp.Status = "partial_success"
+				resp.Message = "some instances failed"
+			}
+			resp.Data.Entries = append(resp.Data.Entries, e)
+		}
+		return resp, nil
+	}
+
+	resp, err := kcclient.Batch(context.Background(), ids, fn,
+		kcclient.WithBatchChunkSize(3),
+		kcclient.WithBatchConcurrency(2),
+	)
+
+	var batchErr *kcclient.BatchError
+	if !errors.As(err, &batchErr) || !errors.Is(err, errUnavailable) {
+		t.Fatalf("expected a batch error wrapping the chunk error, got %v", err)
+	}
+	if got := fmt.Sprint(batchErr.IDs()); got != "[3 4 5]" {
+		t.Errorf("expected the second chunk to fail, got %s", got)
+	}
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", p)
+	}
+
+	// The failed chunk is reported per identifier, so that the entries keep
+	// corresponding to the identifiers.
+	var got, failed []string
+	for _, e := range resp.Data.Entries {
+		got = append(got, e.ID)
+		if e.Error != nil {
+			failed = append(failed, e.ID)
+		}
+	}
+	if fmt.Sprint(got) != "[0 1 2 3 4 5 6 7 8 9]" {
+		t.Errorf("expected entries in request order, got %v", got)
+	}
+	if fmt.Sprint(failed) != "[3 4 5 7]" {
+		t.Errorf("expected error entries for the failed chunk and entry, got %v", failed)
+	}
+	if msg := resp.Data.Entries[3].Message; msg != errUnavailable.Error() {
+		t.Errorf("expected the chunk error in its entries, got %q", msg)
+	}
+
+	if resp.Status != "partial_success" {
+		t.Errorf("expected partial success, got %q", resp.Status)
+	}
+	if _, err := resp.AllOrErr(); err == nil {
+		t.Error("expected AllOrErr to combine the partial failures")
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package client
+
+import "sdk.kraft.cloud/uuid"
+
+const (
+	attrUUID = "uuid"
+	attrName = "name"
+)
+
+// Ref is a typed reference to a resource, either by UUID or by name.  Unlike
+// a plain identifier, whose meaning is derived from its shape, a Ref always
+// refers to the resource by the attribute it was created with.  Services
+// accept Refs wherever they take identifiers.  Refs are comparable and can be
+// used as map keys.
+type Ref struct {
+	attr  string
+	value string
+}
+
+// ByUUID returns a reference to the resource with the given UUID.
+func ByUUID(id string) Ref {
+	return Ref{attr: attrUUID, value: id}
+}
+
+// ByName returns a reference to the resource with the given name, even if the
+// name has the shape of a UUID.
+func ByName(name string) Ref {
+	return Ref{attr: attrName, value: name}
+}
+
+// ParseRef returns a reference to the resource with the given identifier.  The
+// identifier refers to a UUID if it has the shape of one, and to a name
+// otherwise.  Use ByName to refer to a resource whose name has the shape of a
+// UUID.
+func ParseRef(id string) Ref {
+	if uuid.IsValid(id) {
+		return ByUUID(id)
+	}
+
+	return ByName(id)
+}
+
+// UUIDs returns references to the resources with the given UUIDs.
+func UUIDs(uuids ...string) []Ref {
+	refs := make([]Ref, 0, len(uuids))
+	for _, id := range uuids {
+		refs = append(refs, ByUUID(id))
+	}
+
+	return refs
+}
+
+// Attr returns the attribute by which the reference refers to a resource in
+// API requests, either "uuid" or "name".
+func (r Ref) Attr() string {
+	return r.attr
+}
+
+// Value returns the UUID or name of the referenced resource.
+func (r Ref) Value() string {
+	return r.value
+}
+
+// IsZero returns whether the reference refers to no resource.
+func (r Ref) IsZero() bool {
+	return r.value == ""
+}
+
+// String returns the UUID or name of the referenced resource.
+func (r Ref) String() string {
+	return r.value
+}
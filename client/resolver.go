@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultResolverTTL is the default time for which a resolved name is cached.
+const DefaultResolverTTL = time.Minute
+
+// Identity is the UUID and name of a resource.
+type Identity struct {
+	UUID string
+	Name string
+}
+
+// Identified is a response entry which carries the identity of a resource.
+type Identified interface {
+	APIResponseDataEntry
+
+	// Identity returns the UUID and name of the resource.
+	Identity() Identity
+}
+
+// IdentityLookup returns the identities of the referenced resources.
+// Resources which do not exist are omitted.
+type IdentityLookup func(ctx context.Context, refs ...Ref) ([]Identity, error)
+
+// IdentityList returns the identities of all resources.
+type IdentityList func(ctx context.Context) ([]Identity, error)
+
+// NewServiceResolver returns a Resolver which looks up identities with the Get
+// and List methods of a service, e.g.:
+//
+//	resolver := kcclient.NewServiceResolver(client.Instances().Get, client.Instances().List)
+func NewServiceResolver[T Identified](
+	get func(ctx context.Context, refs ...Ref) (*ServiceResponse[T], error),
+	list func(ctx context.Context) (*ServiceResponse[T], error),
+	ropts ...ResolverOption,
+) *Resolver {
+	identities := func(resp *ServiceResponse[T]) []Identity {
+		ids := make([]Identity, 0, len(resp.Data.Entries))
+		for _, entry := range resp.Data.Entries {
+			if entry.ErrorAttributes().Error == nil {
+				ids = append(ids, entry.Identity())
+			}
+		}
+		return ids
+	}
+
+	return NewResolver(
+		func(ctx context.Context, refs ...Ref) ([]Identity, error) {
+			resp, err := get(ctx, refs...)
+			if err != nil {
+				return nil, err
+			}
+			return identities(resp), nil
+		},
+		func(ctx context.Context) ([]Identity, error) {
+			resp, err := list(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return identities(resp), nil
+		},
+		ropts...,
+	)
+}
+
+// ResolvingService keeps a Resolver up to date when resources are created or
+// deleted through the Create and Delete methods of a service, e.g.:
+//
+//	svc := kcclient.NewResolvingService(resolver, client.Instances().Create, client.Instances().Delete)
+type ResolvingService[R any, C Identified, D APIResponseDataEntry] struct {
+	resolver *Resolver
+	create   func(ctx context.Context, req R) (*ServiceResponse[C], error)
+	delete   func(ctx context.Context, refs ...Ref) (*ServiceResponse[D], error)
+}
+
+// NewResolvingService returns a ResolvingService which stores the identities
+// of resources created with create and invalidates resources deleted with
+// delete.
+func NewResolvingService[R any, C Identified, D APIResponseDataEntry](
+	resolver *Resolver,
+	create func(ctx context.Context, req R) (*ServiceResponse[C], error),
+	delete func(ctx context.Context, refs ...Ref) (*ServiceResponse[D], error),
+) *ResolvingService[R, C, D] {
+	return &ResolvingService[R, C, D]{
+		resolver: resolver,
+		create:   create,
+		delete:   delete,
+	}
+}
+
+// Create creates resources and stores the identities of those which were
+// created in the resolver.
+func (s *ResolvingService[R, C, D]) Create(ctx context.Context, req R) (*ServiceResponse[C], error) {
+	resp, err := s.create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range resp.Data.Entries {
+		if entry.ErrorAttributes().Error == nil {
+			s.resolver.Store(entry.Identity())
+		}
+	}
+
+	return resp, nil
+}
+
+// Delete invalidates the resources in the resolver and deletes them.
+func (s *ResolvingService[R, C, D]) Delete(ctx context.Context, refs ...Ref) (*ServiceResponse[D], error) {
+	// Invalidate regardless of the outcome, as the resources may be gone even
+	// if the request failed.
+	s.resolver.Invalidate(refs...)
+
+	return s.delete(ctx, refs...)
+}
+
+// ResolverOption is an option function used during initialization of a
+// Resolver.
+type ResolverOption func(*Resolver)
+
+// WithResolverTTL sets the time for which a resolved name is cached.
+func WithResolverTTL(ttl time.Duration) ResolverOption {
+	return func(r *Resolver) {
+		r.ttl = ttl
+	}
+}
+
+// WithResolverClock sets the function returning the current time.
+func WithResolverClock(now func() time.Time) ResolverOption {
+	return func(r *Resolver) {
+		r.now = now
+	}
+}
+
+// Resolver maps names of resources to their UUIDs and caches the results.
+// A Resolver is bound to the metro of the client it uses for lookups, as
+// names are only unique within a metro.
+type Resolver struct {
+	lookup IdentityLookup
+	list   IdentityList
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]resolverEntry
+}
+
+type resolverEntry struct {
+	uuid    string
+	expires time.Time
+}
+
+// NewResolver instantiates a new Resolver which looks up identities with the
+// given functions.
+func NewResolver(lookup IdentityLookup, list IdentityList, ropts ...ResolverOption) *Resolver {
+	r := &Resolver{
+		lookup:  lookup,
+		list:    list,
+		ttl:     DefaultResolverTTL,
+		now:     time.Now,
+		entries: make(map[string]resolverEntry),
+	}
+
+	for _, opt := range ropts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Resolve returns the UUID of the referenced resource.
+func (r *Resolver) Resolve(ctx context.Context, ref Ref) (string, error) {
+	uuids, err := r.ResolveAll(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	return uuids[0], nil
+}
+
+// ResolveAll returns the UUIDs of the referenced resources in the same order.
+// References by UUID are returned as is, and all names which are not cached
+// are looked up in a single request.
+func (r *Resolver) ResolveAll(ctx context.Context, refs ...Ref) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, errors.New("requires at least one identifier")
+	}
+
+	uuids := make([]string, len(refs))
+	missing := make(map[string][]int)
+
+	r.mu.Lock()
+	now := r.now()
+	for i, ref := range refs {
+		if ref.Attr() == attrUUID {
+			uuids[i] = ref.Value()
+			continue
+		}
+
+		if entry, ok := r.entries[ref.Value()]; ok && now.Before(entry.expires) {
+			uuids[i] = entry.uuid
+			continue
+		}
+
+		missing[ref.Value()] = append(missing[ref.Value()], i)
+	}
+	r.mu.Unlock()
+
+	if len(missing) == 0 {
+		return uuids, nil
+	}
+
+	names := make([]Ref, 0, len(missing))
+	for name := range missing {
+		names = append(names, ByName(name))
+	}
+
+	identities, err := r.lookup(ctx, names...)
+	if err != nil {
+		return nil, fmt.Errorf("looking up names: %w", err)
+	}
+
+	r.Store(identities...)
+
+	for _, identity := range identities {
+		for _, i := range missing[identity.Name] {
+			uuids[i] = identity.UUID
+		}
+		delete(missing, identity.Name)
+	}
+
+	if len(missing) > 0 {
+		var errs []error
+		for name := range missing {
+			errs = append(errs, fmt.Errorf("no resource named '%s'", name))
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	return uuids, nil
+}
+
+// Refresh replaces the cache with the identities of all resources.
+func (r *Resolver) Refresh(ctx context.Context) error {
+	if r.list == nil {
+		return errors.New("resolver cannot list resources")
+	}
+
+	identities, err := r.list(ctx)
+	if err != nil {
+		return fmt.Errorf("listing resources: %w", err)
+	}
+
+	r.Purge()
+	r.Store(identities...)
+
+	return nil
+}
+
+// Store caches the given identities, e.g. after the resources were created.
+func (r *Resolver) Store(identities ...Identity) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expires := r.now().Add(r.ttl)
+	for _, identity := range identities {
+		if identity.UUID == "" || identity.Name == "" {
+			continue
+		}
+		r.entries[identity.Name] = resolverEntry{
+			uuid:    identity.UUID,
+			expires: expires,
+		}
+	}
+}
+
+// Invalidate removes the referenced resources from the cache, e.g. after they
+// were deleted.
+func (r *Resolver) Invalidate(refs ...Ref) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, ref := range refs {
+		if ref.Attr() == attrName {
+			delete(r.entries, ref.Value())
+			continue
+		}
+
+		for name, entry := range r.entries {
+			if entry.uuid == ref.Value() {
+				delete(r.entries, name)
+			}
+		}
+	}
+}
+
+// Purge removes all entries from the cache.
+func (r *Resolver) Purge() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = make(map[string]resolverEntry)
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+const uuid1 = "00000000-0000-0000-0000-000000000001"
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		id    string
+		attr  string
+		value string
+	}{
+		{"app", "name", "app"},
+		{uuid1, "uuid", uuid1},
+		{"uuid:" + uuid1, "name", "uuid:" + uuid1},
+	}
+
+	for _, tt := range tests {
+		if ref := kcclient.ParseRef(tt.id); ref.Attr() != tt.attr || ref.Value() != tt.value {
+			t.Errorf("ParseRef(%q) = %+v, expected (%q, %q)", tt.id, ref, tt.attr, tt.value)
+		}
+	}
+}
+
+func TestRef(t *testing.T) {
+	// A name which has the shape of a UUID does not refer to that UUID.
+	if kcclient.ByName(uuid1) == kcclient.ByUUID(uuid1) {
+		t.Error("expected references by name and by UUID to differ")
+	}
+	if kcclient.ParseRef(uuid1) != kcclient.ByUUID(uuid1) {
+		t.Error("expected a UUID-shaped identifier to refer to the UUID")
+	}
+	if kcclient.ByName(uuid1).String() != uuid1 {
+		t.Error("expected a reference to print its value")
+	}
+	if !(kcclient.Ref{}).IsZero() || kcclient.ByName("app").IsZero() {
+		t.Error("expected only the zero reference to be zero")
+	}
+}
+
+func TestResolver(t *testing.T) {
+	var lookups [][]kcclient.Ref
+
+	lookup := func(ctx context.Context, refs ...kcclient.Ref) ([]kcclient.Identity, error) {
+		lookups = append(lookups, refs)
+
+		var identities []kcclient.Identity
+		for _, ref := range refs {
+			if ref.Value() == "app" {
+				identities = append(identities, kcclient.Identity{UUID: uuid1, Name: "app"})
+			}
+		}
+		return identities, nil
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	r := kcclient.NewResolver(lookup, nil,
+		kcclient.WithResolverTTL(time.Minute),
+		kcclient.WithResolverClock(func() time.Time { return now }),
+	)
+
+	ctx := context.Background()
+
+	resolve := func(ref kcclient.Ref) string {
+		t.Helper()
+		uuid, err := r.Resolve(ctx, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uuid
+	}
+
+	if got := resolve(kcclient.ByName("app")); got != uuid1 {
+		t.Fatalf("expected %s, got %s", uuid1, got)
+	}
+	if got := resolve(kcclient.ByName("app")); got != uuid1 || len(lookups) != 1 {
+		t.Fatalf("expected a cached lookup, got %s after %d lookups", got, len(lookups))
+	}
+	if lookups[0][0] != kcclient.ByName("app") {
+		t.Errorf("expected the lookup to be by name, got %q", lookups[0][0])
+	}
+
+	// UUIDs are never looked up.
+	if got := resolve(kcclient.ByUUID(uuid1)); got != uuid1 || len(lookups) != 1 {
+		t.Fatalf("expected UUID to be returned as is, got %s after %d lookups", got, len(lookups))
+	}
+
+	now = now.Add(2 * time.Minute)
+	resolve(kcclient.ByName("app"))
+	if len(lookups) != 2 {
+		t.Fatalf("expected expired entry to be looked up again, got %d lookups", len(lookups))
+	}
+
+	r.Invalidate(kcclient.ByUUID(uuid1))
+	resolve(kcclient.ByName("app"))
+	if len(lookups) != 3 {
+		t.Fatalf("expected invalidated entry to be looked up again, got %d lookups", len(lookups))
+	}
+
+	if _, err := r.Resolve(ctx, kcclient.ByName("missing")); err == nil {
+		t.Fatal("expected an error for an unknown name")
+	}
+}
+
+// named is a response entry of a resource with a UUID and a name.
+type named struct {
+	UUID string
+	Name string
+
+	kcclient.APIResponseCommon
+}
+
+// Identity implements kcclient.Identified.
+func (n named) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: n.UUID, Name: n.Name}
+}
+
+func TestResolvingService(t *testing.T) {
+	const uuid2 = "00000000-0000-0000-0000-000000000002"
+
+	var gets int
+
+	// The fake service knows "app" and returns an error entry for any other
+	// name.
+	get := func(ctx context.Context, refs ...kcclient.Ref) (*kcclient.ServiceResponse[named], error) {
+		gets++
+
+		resp := &kcclient.ServiceResponse[named]{}
+		for _, ref := range refs {
+			if ref.Value() == "app" {
+				resp.Data.Entries = append(resp.Data.Entries, named{UUID: uuid1, Name: "app"})
+				continue
+			}
+			code := kcclient.APIHTTPError(8)
+			resp.Data.Entries = append(resp.Data.Entries, named{APIResponseCommon: kcclient.APIResponseCommon{Message: "not found", Error: &code}})
+		}
+		return resp, nil
+	}
+	list := func(ctx context.Context) (*kcclient.ServiceResponse[named], error) {
+		return get(ctx, kcclient.ByName("app"))
+	}
+
+	r := kcclient.NewServiceResolver(get, list)
+
+	create := func(ctx context.Context, name string) (*kcclient.ServiceResponse[named], error) {
+		resp := &kcclient.ServiceResponse[named]{}
+		resp.Data.Entries = []named{{UUID: uuid2, Name: name}}
+		return resp, nil
+	}
+	var deleted []kcclient.Ref
+	del := func(ctx context.Context, refs ...kcclient.Ref) (*kcclient.ServiceResponse[named], error) {
+		deleted = append(deleted, refs...)
+		return &kcclient.ServiceResponse[named]{}, nil
+	}
+
+	svc := kcclient.NewResolvingService(r, create, del)
+
+	ctx := context.Background()
+
+	if uuid, err := r.Resolve(ctx, kcclient.ByName("app")); err != nil || uuid != uuid1 {
+		t.Fatalf("expected %s, got %s, %v", uuid1, uuid, err)
+	}
+	if _, err := r.Resolve(ctx, kcclient.ByName("missing")); err == nil {
+		t.Error("expected an error entry not to resolve")
+	}
+
+	// Created resources are resolved without a lookup.
+	if _, err := svc.Create(ctx, "worker"); err != nil {
+		t.Fatal(err)
+	}
+	if uuid, err := r.Resolve(ctx, kcclient.ByName("worker")); err != nil || uuid != uuid2 || gets != 2 {
+		t.Fatalf("expected the created resource to be cached, got %s, %v after %d gets", uuid, err, gets)
+	}
+
+	// Deleted resources are looked up again.
+	if _, err := svc.Delete(ctx, kcclient.ByName("worker")); err != nil || len(deleted) != 1 {
+		t.Fatalf("expected the resource to be deleted, got %v, %v", deleted, err)
+	}
+	if _, err := r.Resolve(ctx, kcclient.ByName("worker")); err == nil || gets != 3 {
+		t.Errorf("expected the deleted resource to be looked up, got %v after %d gets", err, gets)
+	}
+
+	if err := r.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -65,11 +65,25 @@ func (r *ServiceRequest) WithHTTPClient(hc httpclient.HTTPClient) *ServiceReques
 	return rcpy
 }
 
-// Metro returns the metro that this request will perform against.
+// Metro returns the metro that this request will perform against, which is
+// the default metro of the options unless overwritten with WithMetro.
 func (r *ServiceRequest) Metro() string {
+	if r.metro == "" {
+		return r.opts.DefaultMetro()
+	}
+
 	return r.metro
 }
 
+// HTTPClient returns the HTTP client which performs the requests.
+func (r *ServiceRequest) HTTPClient() httpclient.HTTPClient {
+	if r.httpClient != nil {
+		return r.httpClient
+	}
+
+	return r.opts.HTTPClient()
+}
+
 // Metrolink returns the full URI representing the API endpoint of a KraftCloud
 // metro.
 func (r *ServiceRequest) Metrolink(path string) string {
@@ -119,19 +133,46 @@ func (r *ServiceRequest) DoRequest(ctx context.Context, method, url string, body
 	return nil
 }
 
+// DoRawRequest performs the request and returns the unparsed response body.
+// The accept argument is used to negotiate the format of the response with the
+// API.
+func (r *ServiceRequest) DoRawRequest(ctx context.Context, method, url string, body io.Reader, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, r.Metrolink(url), body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the request: %w", err)
+	}
+
+	req.Header.Set("Accept", accept)
+
+	resp, err := r.DoWithAuth(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing the request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp); err != nil {
+		return nil, fmt.Errorf("received an error in the response: %w", err)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	return b, nil
+}
+
 // DoWithAuth performs a request with headers defining the content type.  We
-// also inject the authentication details.
+// also inject the authentication details.  A JSON response is requested unless
+// the request already specifies an accepted content type.
 func (r *ServiceRequest) DoWithAuth(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", r.GetBearerToken())
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	hc := r.opts.HTTPClient()
-	if r.httpClient != nil {
-		hc = r.httpClient
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
 	}
 
-	return hc.Do(req)
+	return r.HTTPClient().Do(req)
 }
 
 // GetBearerToken uses the pre-defined token to construct the header used for
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Timestamp is a point in time reported by the API as an RFC 3339 string.  It
+// embeds the parsed time, so that it can be used like a time.Time, e.g.
+// ts.IsZero() or ts.Before(t).
+//
+// Empty strings and null unmarshal into the zero time.  As long as its time is
+// not changed, a Timestamp marshals back into exactly the representation it
+// was unmarshalled from, and String returns it, so that code which used the
+// former string fields keeps working with fmt and string comparisons via
+// String.
+type Timestamp struct {
+	time.Time
+
+	// raw is the representation of at, which is the time the timestamp was
+	// parsed with.
+	raw  string
+	at   time.Time
+	null bool
+}
+
+// NewTimestamp returns the timestamp of the given time.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// ParseTimestamp parses a timestamp in the representation of the API, i.e. an
+// RFC 3339 string or the empty string.
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("parsing timestamp: %w", err)
+	}
+
+	return Timestamp{Time: t, raw: s, at: t}, nil
+}
+
+// String returns the timestamp in the representation of the API: the string
+// it was parsed from or, if it was created with NewTimestamp or its time was
+// changed since, its time in RFC 3339 format.  The zero timestamp is the empty
+// string.
+func (ts Timestamp) String() string {
+	if ts.Time.Equal(ts.at) {
+		return ts.raw
+	}
+
+	return ts.Time.Format(time.RFC3339Nano)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (ts Timestamp) MarshalJSON() ([]byte, error) {
+	if ts.null && ts.Time.Equal(ts.at) {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(ts.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ts *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*ts = Timestamp{null: true}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("parsing timestamp: %w", err)
+	}
+
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+
+	*ts = parsed
+	return nil
+}
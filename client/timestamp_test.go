@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package client_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+func TestTimestampRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want time.Time
+	}{
+		{"utc", `"2025-01-02T03:04:05Z"`, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"offset and fraction", `"2025-01-02T04:04:05.120+01:00"`, time.Date(2025, 1, 2, 3, 4, 5, 120e6, time.UTC)},
+		{"empty", `""`, time.Time{}},
+		{"null", `null`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v struct {
+				At kcclient.Timestamp `json:"at"`
+			}
+
+			in := `{"at":` + tt.in + `}`
+			if err := json.Unmarshal([]byte(in), &v); err != nil {
+				t.Fatal(err)
+			}
+
+			if !v.At.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, v.At.Time)
+			}
+
+			out, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != in {
+				t.Errorf("expected %s to marshal back identically, got %s", in, out)
+			}
+		})
+	}
+
+	var v kcclient.Timestamp
+	if err := json.Unmarshal([]byte(`"yesterday"`), &v); err == nil {
+		t.Error("expected an error for a malformed timestamp")
+	}
+
+	ts := kcclient.NewTimestamp(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	if ts.String() != "2025-01-02T03:04:05Z" || (kcclient.Timestamp{}).String() != "" {
+		t.Errorf("expected the string in the representation of the API, got %q", ts.String())
+	}
+}
+
+func TestTimestampChanged(t *testing.T) {
+	ts, err := kcclient.ParseTimestamp("2025-01-02T04:04:05.120+01:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts.Time = ts.Add(time.Hour)
+	if out, _ := json.Marshal(ts); string(out) != `"2025-01-02T05:04:05.12+01:00"` {
+		t.Errorf("expected the changed time to be marshalled, got %s", out)
+	}
+
+	var null kcclient.Timestamp
+	if err := json.Unmarshal([]byte(`null`), &null); err != nil {
+		t.Fatal(err)
+	}
+	if err := null.UnmarshalText([]byte("2025-01-02T03:04:05Z")); err != nil {
+		t.Fatal(err)
+	}
+	if null.String() != "2025-01-02T03:04:05Z" {
+		t.Errorf("expected the time set through UnmarshalText, got %q", null.String())
+	}
+}
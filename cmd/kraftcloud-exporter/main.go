@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Command kraftcloud-exporter serves the instance metrics and quotas of a
+// KraftCloud account in the Prometheus exposition format.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/exporter"
+)
+
+func main() {
+	var (
+		listen      = flag.String("listen", ":9765", "address to serve metrics on")
+		metros      = flag.String("metros", "", "comma-separated list of metros to scrape (default: the default metro)")
+		imageQuotas = flag.Bool("image-quotas", true, "whether to collect image storage quotas")
+		timeout     = flag.Duration("timeout", 10*time.Second, "maximum duration of a scrape")
+		refresh     = flag.Duration("refresh", exporter.DefaultRefreshInterval, "interval at which the list of instances is refreshed")
+	)
+	flag.Parse()
+
+	client := kraftcloud.NewClient()
+
+	copts := []exporter.CollectorOption{
+		exporter.WithImageQuotas(*imageQuotas),
+		exporter.WithScrapeTimeout(*timeout),
+		exporter.WithRefreshInterval(*refresh),
+		exporter.WithErrorHandler(func(metro string, err error) {
+			log.Printf("scrape error (metro=%q): %v", metro, err)
+		}),
+	}
+	if *metros != "" {
+		copts = append(copts, exporter.WithMetros(strings.Split(*metros, ",")...))
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(exporter.NewCollector(client, copts...))
+
+	http.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	log.Printf("serving metrics on %s/metrics", *listen)
+	if err := http.ListenAndServe(*listen, nil); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
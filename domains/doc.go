@@ -0,0 +1,8 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package domains relates the domains of service groups on KraftCloud to the
+// certificates which secure them, and rebinds domains to new certificates.
+package domains
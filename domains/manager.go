@@ -0,0 +1,319 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sdk.kraft.cloud/certificates"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/services"
+)
+
+// Certificate is the certificate which secures a domain.
+type Certificate struct {
+	UUID       string             `json:"uuid"`
+	Name       string             `json:"name"`
+	State      certificates.State `json:"state"`
+	CommonName string             `json:"common_name,omitempty"`
+
+	// Attempts is the number of attempts to validate the domain of a pending
+	// certificate.
+	Attempts int `json:"attempts,omitempty"`
+
+	// NextAttempt is the time of the next validation attempt, if any.
+	NextAttempt time.Time `json:"next_attempt,omitzero"`
+
+	// NotBefore and NotAfter bound the validity of the certificate.
+	NotBefore time.Time `json:"not_before,omitzero"`
+	NotAfter  time.Time `json:"not_after,omitzero"`
+}
+
+// Domain is a domain of a service group.
+type Domain struct {
+	// FQDN is the fully qualified name of the domain.
+	FQDN string `json:"fqdn"`
+
+	// ServiceGroup is the service group which serves the domain.
+	ServiceGroup kcclient.Identity `json:"service_group"`
+
+	// Certificate is the certificate which secures the domain, or nil if the
+	// domain is secured by a certificate of the platform.
+	Certificate *Certificate `json:"certificate,omitempty"`
+}
+
+// Manager lists the domains of all service groups together with their
+// certificates and rebinds them to other certificates.
+type Manager struct {
+	services     services.ServicesService
+	certificates certificates.CertificatesService
+}
+
+// NewManager instantiates a new Manager which uses the given clients.  Both
+// clients must use the same metro.
+func NewManager(svc services.ServicesService, certs certificates.CertificatesService) *Manager {
+	return &Manager{
+		services:     svc,
+		certificates: certs,
+	}
+}
+
+// List returns the domains of all service groups.  If only the details of some
+// certificates could not be fetched, the domains are returned alongside the
+// error and their certificates carry what the service groups report.
+func (m *Manager) List(ctx context.Context) ([]Domain, error) {
+	groups, err := m.groups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		domains []Domain
+		certIDs []kcclient.Ref
+		seen    = make(map[kcclient.Ref]bool)
+	)
+
+	for _, group := range groups {
+		for _, d := range group.Domains {
+			domain := Domain{
+				FQDN:         d.FQDN,
+				ServiceGroup: kcclient.Identity{UUID: group.UUID, Name: group.Name},
+			}
+
+			if c := d.Certificate; c != nil {
+				domain.Certificate = &Certificate{
+					UUID:  c.UUID,
+					Name:  c.Name,
+					State: certificates.State(c.State),
+				}
+
+				id := certificateID(c.UUID, c.Name)
+				if !id.IsZero() && !seen[id] {
+					seen[id] = true
+					certIDs = append(certIDs, id)
+				}
+			}
+
+			domains = append(domains, domain)
+		}
+	}
+
+	if len(certIDs) == 0 {
+		return domains, nil
+	}
+
+	certs, err := kcclient.Batch(ctx, certIDs, m.certificates.Get)
+	if certs == nil {
+		return domains, fmt.Errorf("getting certificates: %w", err)
+	}
+
+	// The entries correspond to the identifiers, including those of failed
+	// chunks, which carry an error.
+	all, allErr := certs.AllOrErr()
+	details := make(map[kcclient.Ref]certificates.GetResponseItem, len(all))
+	for i, cert := range all {
+		if cert.ErrorAttributes().Error != nil {
+			continue
+		}
+		details[certIDs[i]] = cert
+	}
+
+	for i := range domains {
+		c := domains[i].Certificate
+		if c == nil {
+			continue
+		}
+
+		if cert, ok := details[certificateID(c.UUID, c.Name)]; ok {
+			*c = certificate(cert)
+		}
+	}
+
+	if err := errors.Join(err, allErr); err != nil {
+		return domains, fmt.Errorf("getting certificates: %w", err)
+	}
+
+	return domains, nil
+}
+
+// Get returns the domain with the given name.
+func (m *Manager) Get(ctx context.Context, fqdn string) (*Domain, error) {
+	domains, err := m.List(ctx)
+	if domains == nil && err != nil {
+		return nil, err
+	}
+
+	for _, domain := range domains {
+		if sameDomain(domain.FQDN, fqdn) {
+			return &domain, err
+		}
+	}
+
+	return nil, fmt.Errorf("domain '%s' not found", fqdn)
+}
+
+// Rebind secures the domain with the given name with the referenced
+// certificate and returns the updated domain.
+//
+// The certificate must be valid and its common name must match the domain.
+// The domain is switched over in a single operation, so that it is served with
+// either the old or the new certificate at any time.  If the service group does
+// not report the new certificate afterwards, the old certificate is restored.
+func (m *Manager) Rebind(ctx context.Context, fqdn string, certificate kcclient.Ref) (*Domain, error) {
+	domain, err := m.Get(ctx, fqdn)
+	if domain == nil {
+		return nil, err
+	}
+
+	resp, err := m.certificates.Get(ctx, certificate)
+	if err != nil {
+		return nil, fmt.Errorf("getting certificate: %w", err)
+	}
+
+	cert, err := resp.FirstOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("getting certificate: %w", err)
+	}
+
+	if cert.State != certificates.StateValid {
+		return nil, fmt.Errorf("certificate '%s' is %s, not %s", cert.Name, cert.State, certificates.StateValid)
+	}
+	if cert.CommonName != "" && !matchesName(cert.CommonName, domain.FQDN) {
+		return nil, fmt.Errorf("certificate '%s' is issued for '%s', not '%s'", cert.Name, cert.CommonName, domain.FQDN)
+	}
+
+	if domain.Certificate != nil && domain.Certificate.UUID == cert.UUID {
+		return domain, nil
+	}
+
+	group := kcclient.ByUUID(domain.ServiceGroup.UUID)
+
+	if err := m.patch(ctx, group, services.AttachCertificate(domain.FQDN, kcclient.ByUUID(cert.UUID))); err != nil {
+		return nil, fmt.Errorf("rebinding domain '%s': %w", domain.FQDN, err)
+	}
+
+	updated, err := m.Get(ctx, domain.FQDN)
+	if updated != nil && updated.Certificate != nil && updated.Certificate.UUID == cert.UUID {
+		return updated, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("service group does not report certificate '%s'", cert.Name)
+	}
+
+	restore := services.DetachCertificate(domain.FQDN)
+	if old := domain.Certificate; old != nil {
+		restore = services.AttachCertificate(domain.FQDN, certificateID(old.UUID, old.Name))
+	}
+
+	if rerr := m.patch(ctx, group, restore); rerr != nil {
+		return nil, fmt.Errorf("verifying rebind of domain '%s': %w (restoring: %w)", domain.FQDN, err, rerr)
+	}
+
+	return nil, fmt.Errorf("verifying rebind of domain '%s': %w (restored)", domain.FQDN, err)
+}
+
+// groups returns the full description of all service groups.
+func (m *Manager) groups(ctx context.Context) ([]services.GetResponseItem, error) {
+	list, err := m.services.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing service groups: %w", err)
+	}
+
+	groups, err := list.AllOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("listing service groups: %w", err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]kcclient.Ref, len(groups))
+	for i, group := range groups {
+		ids[i] = kcclient.ByUUID(group.UUID)
+	}
+
+	resp, err := kcclient.Batch(ctx, ids, m.services.Get)
+	if err != nil {
+		return nil, fmt.Errorf("getting service groups: %w", err)
+	}
+
+	groups, err = resp.AllOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("getting service groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// patch applies the operation to the service group.
+func (m *Manager) patch(ctx context.Context, group kcclient.Ref, op services.PatchOperation) error {
+	resp, err := m.services.Patch(ctx, group, op)
+	if err != nil {
+		return err
+	}
+
+	_, err = resp.FirstOrErr()
+	return err
+}
+
+// certificate converts the description of a certificate.
+func certificate(cert certificates.GetResponseItem) Certificate {
+	c := Certificate{
+		UUID:       cert.UUID,
+		Name:       cert.Name,
+		State:      cert.State,
+		CommonName: cert.CommonName,
+		NotBefore:  cert.NotBefore.Time,
+		NotAfter:   cert.NotAfter.Time,
+	}
+
+	if v := cert.Validation; v != nil {
+		c.Attempts = v.Attempt
+		c.NextAttempt = v.Next.Time
+	}
+
+	return c
+}
+
+// certificateID returns a reference to a certificate, preferring its UUID.
+func certificateID(uuid, name string) kcclient.Ref {
+	if uuid != "" {
+		return kcclient.ByUUID(uuid)
+	}
+	return kcclient.ByName(name)
+}
+
+// normalize returns the name of the domain in lower case and without a
+// trailing dot.
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// sameDomain reports whether the names refer to the same domain.
+func sameDomain(a, b string) bool {
+	return normalize(a) == normalize(b)
+}
+
+// matchesName reports whether a certificate issued for the common name covers
+// the domain, including wildcards which cover a single label.
+func matchesName(cn, fqdn string) bool {
+	cn, fqdn = normalize(cn), normalize(fqdn)
+
+	if cn == fqdn {
+		return true
+	}
+
+	if suffix, ok := strings.CutPrefix(cn, "*."); ok {
+		label, rest, found := strings.Cut(fqdn, ".")
+		return found && label != "" && rest == suffix
+	}
+
+	return false
+}
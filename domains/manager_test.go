@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package domains_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/certificates"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/domains"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+// newManager serves a service group with a custom domain secured by the
+// certificate c1 and certificates in any state.
+func newManager(t *testing.T) (*domains.Manager, *fakeapi.ServiceGroup) {
+	api := fakeapi.New(t)
+
+	group := &fakeapi.ServiceGroup{
+		UUID: "g1",
+		Name: "web",
+		Domains: []fakeapi.Domain{
+			{FQDN: "web.fra0.kraft.app"},
+			{FQDN: "example.com", Certificate: "c1"},
+		},
+	}
+	api.ServeServiceGroup(group)
+
+	api.Handle(http.MethodGet, "/certificates", "certificates", func(r *fakeapi.Request) []string {
+		var entries []string
+		for i := range r.Items {
+			uuid := r.String(i, "uuid")
+			state := "valid"
+			if uuid == "pending" {
+				state = "pending"
+			}
+			entries = append(entries, fmt.Sprintf(`{"status":"success","uuid":%q,"name":%[1]q,"common_name":"example.com",`+
+				`"state":%q,"not_after":"2030-01-01T00:00:00Z","validation":{"attempt":2}}`, uuid, state))
+		}
+		return entries
+	})
+
+	return domains.NewManager(
+		kraftcloud.NewServicesClient().WithMetro(api.URL),
+		kraftcloud.NewCertificatesClient().WithMetro(api.URL),
+	), group
+}
+
+func TestList(t *testing.T) {
+	manager, _ := newManager(t)
+
+	list, err := manager.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list) != 2 || list[0].Certificate != nil || list[1].ServiceGroup.Name != "web" {
+		t.Fatalf("unexpected domains %+v", list)
+	}
+
+	cert := list[1].Certificate
+	if cert == nil || cert.UUID != "c1" || cert.State != certificates.StateValid || cert.Attempts != 2 || cert.NotAfter.Year() != 2030 {
+		t.Errorf("expected the details of the certificate, got %+v", cert)
+	}
+}
+
+func TestRebind(t *testing.T) {
+	manager, group := newManager(t)
+
+	ctx := context.Background()
+
+	if _, err := manager.Rebind(ctx, "example.com", kcclient.ByUUID("pending")); err == nil {
+		t.Error("expected an error for a pending certificate")
+	}
+
+	domain, err := manager.Rebind(ctx, "Example.com.", kcclient.ByUUID("c2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if domain.Certificate.UUID != "c2" || len(group.Patches) != 1 {
+		t.Errorf("expected the domain to be rebound with a single patch, got %+v after %v", domain.Certificate, group.Patches)
+	}
+
+	group.IgnorePatches = true
+
+	if _, err := manager.Rebind(ctx, "example.com", kcclient.ByUUID("c3")); err == nil || !strings.Contains(err.Error(), "restored") {
+		t.Fatalf("expected the rebind to be restored, got %v", err)
+	}
+
+	restore := group.Patches[len(group.Patches)-1]
+	if value, _ := restore["value"].(map[string]any); value["uuid"] != "c2" {
+		t.Errorf("expected the previous certificate to be restored, got %v", restore)
+	}
+}
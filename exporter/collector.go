@@ -0,0 +1,598 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+// Namespace is the prefix of all metrics exposed by the Collector.
+const Namespace = "kraftcloud"
+
+// DefaultRefreshInterval is the default interval at which the inventory of
+// instances of a metro is refreshed.
+const DefaultRefreshInterval = 5 * time.Minute
+
+// DefaultBootTimeBuckets are the default buckets, in seconds, of the boot and
+// network time histograms.
+var DefaultBootTimeBuckets = []float64{
+	.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5,
+}
+
+var (
+	instanceLabels  = []string{"metro", "name", "uuid", "image", "state", "service_group"}
+	histogramLabels = []string{"metro", "image", "service_group"}
+
+	upDesc = prometheus.NewDesc(
+		Namespace+"_up",
+		"Whether the last scrape of the metro was successful.",
+		[]string{"metro"}, nil,
+	)
+	scrapeErrorsDesc = prometheus.NewDesc(
+		Namespace+"_scrape_errors",
+		"Number of instances which could not be listed or scraped during the last scrape of the metro.",
+		[]string{"metro"}, nil,
+	)
+	rssDesc = prometheus.NewDesc(
+		Namespace+"_instance_rss_bytes",
+		"Resident memory size of the instance.",
+		instanceLabels, nil,
+	)
+	uptimeDesc = prometheus.NewDesc(
+		Namespace+"_instance_uptime_seconds",
+		"Uptime of the instance.",
+		instanceLabels, nil,
+	)
+	cpuDesc = prometheus.NewDesc(
+		Namespace+"_instance_cpu_seconds_total",
+		"Active running time of the instance.",
+		instanceLabels, nil,
+	)
+	rxBytesDesc = prometheus.NewDesc(
+		Namespace+"_instance_receive_bytes_total",
+		"Amount of bytes received over the network.",
+		instanceLabels, nil,
+	)
+	rxPacketsDesc = prometheus.NewDesc(
+		Namespace+"_instance_receive_packets_total",
+		"Count of packets received from the network.",
+		instanceLabels, nil,
+	)
+	txBytesDesc = prometheus.NewDesc(
+		Namespace+"_instance_transmit_bytes_total",
+		"Amount of bytes transmitted over the network.",
+		instanceLabels, nil,
+	)
+	txPacketsDesc = prometheus.NewDesc(
+		Namespace+"_instance_transmit_packets_total",
+		"Count of packets transmitted over the network.",
+		instanceLabels, nil,
+	)
+	connectionsDesc = prometheus.NewDesc(
+		Namespace+"_instance_connections",
+		"Number of currently established inbound connections (non-HTTP).",
+		instanceLabels, nil,
+	)
+	requestsDesc = prometheus.NewDesc(
+		Namespace+"_instance_inflight_requests",
+		"Number of in-flight HTTP requests.",
+		instanceLabels, nil,
+	)
+	queuedDesc = prometheus.NewDesc(
+		Namespace+"_instance_queued",
+		"Number of queued inbound connections and HTTP requests.",
+		instanceLabels, nil,
+	)
+	handledDesc = prometheus.NewDesc(
+		Namespace+"_instance_handled_total",
+		"Total number of inbound connections and HTTP requests handled.",
+		instanceLabels, nil,
+	)
+	startsDesc = prometheus.NewDesc(
+		Namespace+"_instance_starts_total",
+		"Total number of times the instance has been started.",
+		instanceLabels, nil,
+	)
+	restartsDesc = prometheus.NewDesc(
+		Namespace+"_instance_restarts_total",
+		"Total number of times the instance has been restarted by its restart policy.",
+		instanceLabels, nil,
+	)
+	bootTimeDesc = prometheus.NewDesc(
+		Namespace+"_instance_boot_time_seconds",
+		"Time from start of the instance to finish booting of Unikraft.",
+		histogramLabels, nil,
+	)
+	netTimeDesc = prometheus.NewDesc(
+		Namespace+"_instance_net_time_seconds",
+		"Time from start of the instance to its first listening socket.",
+		histogramLabels, nil,
+	)
+	quotaUsedDesc = prometheus.NewDesc(
+		Namespace+"_quota_used",
+		"Current usage of an account resource.",
+		[]string{"metro", "resource"}, nil,
+	)
+	quotaHardDesc = prometheus.NewDesc(
+		Namespace+"_quota_hard",
+		"Hard limit of an account resource.",
+		[]string{"metro", "resource"}, nil,
+	)
+	imageStorageUsedDesc = prometheus.NewDesc(
+		Namespace+"_image_storage_used_bytes",
+		"Registry storage used by the images of the account.",
+		nil, nil,
+	)
+	imageStorageHardDesc = prometheus.NewDesc(
+		Namespace+"_image_storage_hard_bytes",
+		"Registry storage limit of the account.",
+		nil, nil,
+	)
+)
+
+// Collector is a prometheus.Collector which turns the instance metrics, user
+// quotas and image quotas of a KraftCloud account into Prometheus metrics.
+//
+// Every scrape performs the following API calls:
+//
+//   - per metro, one batched call which retrieves the metrics of all known
+//     instances, and one call which retrieves the user quotas;
+//   - per metro, one call which lists the instances, but only if the cached
+//     inventory of instances, which provides the image and service group
+//     labels, is older than the refresh interval or a previous scrape failed
+//     to retrieve the metrics of a known instance;
+//   - once, unless disabled with WithImageQuotas, one call which retrieves the
+//     image quotas, which are not bound to a metro.
+//
+// The boot and network time histograms are cumulative: every start of an
+// instance is observed once, when a scrape first sees its start count.
+type Collector struct {
+	client          kraftcloud.KraftCloud
+	metros          []string
+	imageQuotas     bool
+	timeout         time.Duration
+	refreshInterval time.Duration
+	buckets         []float64
+	onError         func(string, error)
+
+	mu          sync.Mutex
+	inventories map[string]*inventory
+	starts      map[instanceKey]uint
+	histograms  map[histogramKey]*histogram
+}
+
+// inventory is the cached list of instances of a single metro.
+type inventory struct {
+	refreshedAt time.Time
+	instances   []instances.GetResponseItem
+}
+
+// instanceKey identifies an instance across metros.
+type instanceKey struct {
+	metro string
+	uuid  string
+}
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// NewCollector instantiates a new Collector which uses the given client to
+// speak with the KraftCloud API.
+func NewCollector(client kraftcloud.KraftCloud, copts ...CollectorOption) *Collector {
+	c := &Collector{
+		client:          client,
+		imageQuotas:     true,
+		timeout:         10 * time.Second,
+		refreshInterval: DefaultRefreshInterval,
+		buckets:         DefaultBootTimeBuckets,
+		inventories:     make(map[string]*inventory),
+		starts:          make(map[instanceKey]uint),
+		histograms:      make(map[histogramKey]*histogram),
+	}
+
+	for _, opt := range copts {
+		opt(c)
+	}
+
+	// Without explicit metros, only the metro of the client is queried.
+	if len(c.metros) == 0 {
+		metro := kcclient.DefaultMetro
+		if m, ok := client.Instances().(interface{ Metro() string }); ok && m.Metro() != "" {
+			metro = m.Metro()
+		}
+		c.metros = []string{metro}
+	}
+	if c.refreshInterval <= 0 {
+		c.refreshInterval = DefaultRefreshInterval
+	}
+
+	return c
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		upDesc,
+		scrapeErrorsDesc,
+		rssDesc,
+		uptimeDesc,
+		cpuDesc,
+		rxBytesDesc,
+		rxPacketsDesc,
+		txBytesDesc,
+		txPacketsDesc,
+		connectionsDesc,
+		requestsDesc,
+		queuedDesc,
+		handledDesc,
+		startsDesc,
+		restartsDesc,
+		bootTimeDesc,
+		netTimeDesc,
+		quotaUsedDesc,
+		quotaHardDesc,
+	} {
+		ch <- desc
+	}
+
+	if c.imageQuotas {
+		ch <- imageStorageUsedDesc
+		ch <- imageStorageHardDesc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for _, metro := range c.metros {
+		wg.Add(1)
+		go func(metro string) {
+			defer wg.Done()
+
+			up := 1.0
+			failed, err := c.collectMetro(ctx, metro, ch)
+			if err != nil {
+				up = 0
+				c.handleError(metro, err)
+			}
+			for _, err := range failed {
+				c.handleError(metro, err)
+			}
+
+			ch <- prometheus.MustNewConstMetric(upDesc, prometheus.GaugeValue, up, metro)
+			ch <- prometheus.MustNewConstMetric(scrapeErrorsDesc, prometheus.GaugeValue, float64(len(failed)), metro)
+		}(metro)
+	}
+
+	if c.imageQuotas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			quota, err := c.client.Images().Quotas(ctx)
+			if err != nil {
+				c.handleError("", fmt.Errorf("getting image quotas: %w", err))
+				return
+			}
+
+			ch <- prometheus.MustNewConstMetric(imageStorageUsedDesc, prometheus.GaugeValue, float64(quota.Used))
+			ch <- prometheus.MustNewConstMetric(imageStorageHardDesc, prometheus.GaugeValue, float64(quota.Hard))
+		}()
+	}
+
+	wg.Wait()
+}
+
+// collectMetro sends all metrics of a single metro to ch.  It returns the
+// errors of individual instances which could not be retrieved, as well as an
+// error if the metro could not be scraped completely.
+func (c *Collector) collectMetro(ctx context.Context, metro string, ch chan<- prometheus.Metric) (failed []error, err error) {
+	quotaErr := c.collectQuotas(ctx, metro, ch)
+
+	inv, failed, err := c.inventory(ctx, metro)
+	if err != nil {
+		return failed, errors.Join(quotaErr, err)
+	}
+
+	instanceFailed, err := c.collectInstances(ctx, metro, inv.instances, ch)
+	failed = append(failed, instanceFailed...)
+
+	// The metrics of a known instance are typically missing because it was
+	// deleted, so refresh the inventory on the next scrape.
+	if len(instanceFailed) > 0 {
+		c.invalidate(metro, inv)
+	}
+
+	return failed, errors.Join(quotaErr, err)
+}
+
+// inventory returns the cached inventory of a metro, and refreshes it if it is
+// older than the refresh interval.  Instances which could not be listed are
+// returned as failures.
+func (c *Collector) inventory(ctx context.Context, metro string) (*inventory, []error, error) {
+	c.mu.Lock()
+	inv := c.inventories[metro]
+	c.mu.Unlock()
+
+	if inv != nil && time.Since(inv.refreshedAt) < c.refreshInterval {
+		return inv, nil, nil
+	}
+
+	listResp, err := c.client.Instances().WithMetro(metro).List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing instances: %w", err)
+	}
+
+	// Partial failures still carry the healthy instances, so only bail out if
+	// no entries were returned at all.
+	list, err := listResp.AllOrErr()
+	if len(list) == 0 && err != nil {
+		return nil, nil, fmt.Errorf("listing instances: %w", err)
+	}
+
+	inv = &inventory{refreshedAt: time.Now()}
+
+	var failed []error
+	for _, instance := range list {
+		if instance.Error != nil {
+			failed = append(failed, fmt.Errorf("listing instance %s: %s (code=%d)", instanceID(instance.UUID, instance.Name), instance.Message, *instance.Error))
+			continue
+		}
+		if instance.State == instances.InstanceStateTemplate {
+			continue
+		}
+		inv.instances = append(inv.instances, instance)
+	}
+
+	c.mu.Lock()
+	c.inventories[metro] = inv
+
+	// Forget the starts of instances which no longer exist.
+	known := make(map[string]bool, len(inv.instances))
+	for _, instance := range inv.instances {
+		known[instance.UUID] = true
+	}
+	for key := range c.starts {
+		if key.metro == metro && !known[key.uuid] {
+			delete(c.starts, key)
+		}
+	}
+	c.mu.Unlock()
+
+	return inv, failed, nil
+}
+
+// invalidate drops the cached inventory of a metro, unless it was already
+// replaced by a newer one.
+func (c *Collector) invalidate(metro string, inv *inventory) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.inventories[metro] == inv {
+		delete(c.inventories, metro)
+	}
+}
+
+// collectQuotas sends the user quotas of a single metro to ch.
+func (c *Collector) collectQuotas(ctx context.Context, metro string, ch chan<- prometheus.Metric) error {
+	resp, err := c.client.Users().WithMetro(metro).Quotas(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user quotas: %w", err)
+	}
+
+	quota, err := resp.FirstOrErr()
+	if err != nil {
+		return fmt.Errorf("getting user quotas: %w", err)
+	}
+
+	used := map[string]int{
+		"instances":       quota.Used.Instances,
+		"live_instances":  quota.Used.LiveInstances,
+		"live_vcpus":      quota.Used.LiveVcpus,
+		"live_memory_mb":  quota.Used.LiveMemoryMb,
+		"service_groups":  quota.Used.ServiceGroups,
+		"services":        quota.Used.Services,
+		"volumes":         quota.Used.Volumes,
+		"total_volume_mb": quota.Used.TotalVolumeMb,
+	}
+	for resource, value := range used {
+		ch <- prometheus.MustNewConstMetric(quotaUsedDesc, prometheus.GaugeValue, float64(value), metro, resource)
+	}
+
+	hard := map[string]int{
+		"instances":       quota.Hard.Instances,
+		"live_vcpus":      quota.Hard.LiveVcpus,
+		"live_memory_mb":  quota.Hard.LiveMemoryMb,
+		"service_groups":  quota.Hard.ServiceGroups,
+		"services":        quota.Hard.Services,
+		"volumes":         quota.Hard.Volumes,
+		"total_volume_mb": quota.Hard.TotalVolumeMb,
+	}
+	for resource, value := range hard {
+		ch <- prometheus.MustNewConstMetric(quotaHardDesc, prometheus.GaugeValue, float64(value), metro, resource)
+	}
+
+	return nil
+}
+
+// collectInstances sends the metrics of the given instances of a single metro
+// to ch, using a single batched request.  It returns the errors of instances
+// whose metrics could not be retrieved.
+func (c *Collector) collectInstances(ctx context.Context, metro string, list []instances.GetResponseItem, ch chan<- prometheus.Metric) ([]error, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	details := make(map[string]instances.GetResponseItem, len(list))
+	uuids := make([]string, 0, len(list))
+	for _, instance := range list {
+		details[instance.UUID] = instance
+		uuids = append(uuids, instance.UUID)
+	}
+
+	metricsResp, err := c.client.Instances().WithMetro(metro).Metrics(ctx, kcclient.UUIDs(uuids...)...)
+	if err != nil {
+		return nil, fmt.Errorf("getting instance metrics: %w", err)
+	}
+
+	// Partial failures still carry the metrics of the healthy instances, so
+	// only bail out if no entries were returned at all.
+	items, err := metricsResp.AllOrErr()
+	if len(items) == 0 && err != nil {
+		return nil, fmt.Errorf("getting instance metrics: %w", err)
+	}
+
+	var failed []error
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, item := range items {
+		if item.Error != nil {
+			failed = append(failed, fmt.Errorf("getting metrics of instance %s: %s (code=%d)", instanceID(item.UUID, item.Name), item.Message, *item.Error))
+			continue
+		}
+
+		detail := details[item.UUID]
+
+		serviceGroup := ""
+		if detail.ServiceGroup != nil {
+			serviceGroup = detail.ServiceGroup.Name
+		}
+
+		labels := []string{
+			metro,
+			item.Name,
+			item.UUID,
+			detail.Image,
+			string(item.State),
+			serviceGroup,
+		}
+
+		for _, m := range []struct {
+			desc      *prometheus.Desc
+			valueType prometheus.ValueType
+			value     float64
+		}{
+			{rssDesc, prometheus.GaugeValue, float64(item.RSS)},
+			{uptimeDesc, prometheus.GaugeValue, float64(item.UptimeMs) / 1e3},
+			{cpuDesc, prometheus.CounterValue, float64(item.CPUTimeMs) / 1e3},
+			{rxBytesDesc, prometheus.CounterValue, float64(item.RxBytes)},
+			{rxPacketsDesc, prometheus.CounterValue, float64(item.RxPackets)},
+			{txBytesDesc, prometheus.CounterValue, float64(item.TxBytes)},
+			{txPacketsDesc, prometheus.CounterValue, float64(item.TxPackets)},
+			{connectionsDesc, prometheus.GaugeValue, float64(item.Connections)},
+			{requestsDesc, prometheus.GaugeValue, float64(item.Requests)},
+			{queuedDesc, prometheus.GaugeValue, float64(item.Queued)},
+			{handledDesc, prometheus.CounterValue, float64(item.Total)},
+			{startsDesc, prometheus.CounterValue, float64(item.StartCount)},
+			{restartsDesc, prometheus.CounterValue, float64(item.RestartCount)},
+		} {
+			ch <- prometheus.MustNewConstMetric(m.desc, m.valueType, m.value, labels...)
+		}
+
+		// The boot and network times only change when the instance starts,
+		// so observe them once per start.
+		instance := instanceKey{metro: metro, uuid: item.UUID}
+		if starts, ok := c.starts[instance]; ok && starts == item.StartCount {
+			continue
+		}
+		c.starts[instance] = item.StartCount
+
+		// Instances which have never booted report a zero value, which would
+		// otherwise skew the lowest bucket.
+		if item.BootTimeUs > 0 {
+			c.observe(histogramKey{bootTimeDesc, metro, detail.Image, serviceGroup}, float64(item.BootTimeUs)/1e6)
+		}
+		if item.NetTimeUs > 0 {
+			c.observe(histogramKey{netTimeDesc, metro, detail.Image, serviceGroup}, float64(item.NetTimeUs)/1e6)
+		}
+	}
+
+	for key, h := range c.histograms {
+		if key.metro == metro {
+			ch <- h.metric(key)
+		}
+	}
+
+	return failed, nil
+}
+
+// instanceID returns the UUID of an instance, or its name if the UUID is
+// unknown.
+func instanceID(uuid, name string) string {
+	if uuid != "" {
+		return uuid
+	}
+	return name
+}
+
+// histogramKey identifies a cumulative histogram.
+type histogramKey struct {
+	desc         *prometheus.Desc
+	metro        string
+	image        string
+	serviceGroup string
+}
+
+// histogram accumulates the observations of a histogram across scrapes.
+type histogram struct {
+	bounds  []float64
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// observe adds an observation to the histogram identified by key.  The caller
+// must hold c.mu.
+func (c *Collector) observe(key histogramKey, value float64) {
+	h, ok := c.histograms[key]
+	if !ok {
+		h = &histogram{
+			bounds:  c.buckets,
+			buckets: make([]uint64, len(c.buckets)),
+		}
+		c.histograms[key] = h
+	}
+
+	for i, bound := range h.bounds {
+		if value <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+// metric returns the current state of the histogram as a constant metric.
+func (h *histogram) metric(key histogramKey) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(h.bounds))
+	for i, bound := range h.bounds {
+		buckets[bound] = h.buckets[i]
+	}
+
+	return prometheus.MustNewConstHistogram(key.desc, h.count, h.sum, buckets, key.metro, key.image, key.serviceGroup)
+}
+
+// handleError forwards err to the configured error handler, if any.
+func (c *Collector) handleError(metro string, err error) {
+	if c.onError != nil {
+		c.onError(metro, err)
+	}
+}
@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package exporter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/exporter"
+)
+
+const (
+	uuid1 = "00000000-0000-0000-0000-000000000001"
+	uuid2 = "00000000-0000-0000-0000-000000000002"
+)
+
+func TestCollector(t *testing.T) {
+	var listCalls, metricsCalls, quotaCalls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instances":
+			listCalls.Add(1)
+			fmt.Fprint(w, `{"data":{"instances":[`+
+				`{"uuid":"`+uuid1+`","name":"a","image":"nginx:latest","state":"running","service_group":{"name":"web"}},`+
+				`{"uuid":"`+uuid2+`","name":"b","image":"nginx:latest","state":"running","service_group":{"name":"web"}}`+
+				`]}}`)
+
+		case "/instances/metrics":
+			metricsCalls.Add(1)
+
+			b, _ := io.ReadAll(r.Body)
+			var req []map[string]string
+			if err := json.Unmarshal(b, &req); err != nil || len(req) != 2 {
+				http.Error(w, "expected a single batched request", http.StatusBadRequest)
+				return
+			}
+
+			fmt.Fprint(w, `{"data":{"instances":[`+
+				`{"uuid":"`+uuid1+`","name":"a","state":"running","rss_bytes":1024,"boot_time_us":2000},`+
+				`{"uuid":"`+uuid2+`","name":"b","state":"running","rss_bytes":2048,"boot_time_us":30000}`+
+				`]}}`)
+
+		case "/users/quotas":
+			quotaCalls.Add(1)
+			fmt.Fprint(w, `{"data":{"quotas":[{"used":{"instances":2,"live_vcpus":1},"hard":{"instances":16,"live_vcpus":8}}]}}`)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	collector := exporter.NewCollector(
+		kraftcloud.NewClient(kraftcloud.WithToken("token")),
+		exporter.WithMetros(srv.URL),
+		exporter.WithImageQuotas(false),
+		exporter.WithBootTimeBuckets(.005, .05),
+		exporter.WithErrorHandler(func(metro string, err error) {
+			t.Errorf("unexpected error in metro %s: %v", metro, err)
+		}),
+	)
+
+	expected := strings.ReplaceAll(`
+# HELP kraftcloud_instance_boot_time_seconds Time from start of the instance to finish booting of Unikraft.
+# TYPE kraftcloud_instance_boot_time_seconds histogram
+kraftcloud_instance_boot_time_seconds_bucket{image="nginx:latest",metro="METRO",service_group="web",le="0.005"} 1
+kraftcloud_instance_boot_time_seconds_bucket{image="nginx:latest",metro="METRO",service_group="web",le="0.05"} 2
+kraftcloud_instance_boot_time_seconds_bucket{image="nginx:latest",metro="METRO",service_group="web",le="+Inf"} 2
+kraftcloud_instance_boot_time_seconds_sum{image="nginx:latest",metro="METRO",service_group="web"} 0.032
+kraftcloud_instance_boot_time_seconds_count{image="nginx:latest",metro="METRO",service_group="web"} 2
+# HELP kraftcloud_instance_rss_bytes Resident memory size of the instance.
+# TYPE kraftcloud_instance_rss_bytes gauge
+kraftcloud_instance_rss_bytes{image="nginx:latest",metro="METRO",name="a",service_group="web",state="running",uuid="`+uuid1+`"} 1024
+kraftcloud_instance_rss_bytes{image="nginx:latest",metro="METRO",name="b",service_group="web",state="running",uuid="`+uuid2+`"} 2048
+# HELP kraftcloud_quota_hard Hard limit of an account resource.
+# TYPE kraftcloud_quota_hard gauge
+kraftcloud_quota_hard{metro="METRO",resource="instances"} 16
+kraftcloud_quota_hard{metro="METRO",resource="live_memory_mb"} 0
+kraftcloud_quota_hard{metro="METRO",resource="live_vcpus"} 8
+kraftcloud_quota_hard{metro="METRO",resource="service_groups"} 0
+kraftcloud_quota_hard{metro="METRO",resource="services"} 0
+kraftcloud_quota_hard{metro="METRO",resource="total_volume_mb"} 0
+kraftcloud_quota_hard{metro="METRO",resource="volumes"} 0
+# HELP kraftcloud_quota_used Current usage of an account resource.
+# TYPE kraftcloud_quota_used gauge
+kraftcloud_quota_used{metro="METRO",resource="instances"} 2
+kraftcloud_quota_used{metro="METRO",resource="live_instances"} 0
+kraftcloud_quota_used{metro="METRO",resource="live_memory_mb"} 0
+kraftcloud_quota_used{metro="METRO",resource="live_vcpus"} 1
+kraftcloud_quota_used{metro="METRO",resource="service_groups"} 0
+kraftcloud_quota_used{metro="METRO",resource="services"} 0
+kraftcloud_quota_used{metro="METRO",resource="total_volume_mb"} 0
+kraftcloud_quota_used{metro="METRO",resource="volumes"} 0
+# HELP kraftcloud_scrape_errors Number of instances which could not be listed or scraped during the last scrape of the metro.
+# TYPE kraftcloud_scrape_errors gauge
+kraftcloud_scrape_errors{metro="METRO"} 0
+# HELP kraftcloud_up Whether the last scrape of the metro was successful.
+# TYPE kraftcloud_up gauge
+kraftcloud_up{metro="METRO"} 1
+`, "METRO", srv.URL)
+
+	// The second scrape serves the labels from the cached inventory, and does
+	// not observe the boot times of the same starts again.
+	for range 2 {
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"kraftcloud_instance_boot_time_seconds",
+			"kraftcloud_instance_rss_bytes",
+			"kraftcloud_quota_hard",
+			"kraftcloud_quota_used",
+			"kraftcloud_scrape_errors",
+			"kraftcloud_up",
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls := metricsCalls.Load(); calls != 2 {
+		t.Errorf("expected 1 metrics call per scrape, got %d", calls)
+	}
+	if calls := listCalls.Load(); calls != 1 {
+		t.Errorf("expected the instances to be listed once, got %d", calls)
+	}
+	if calls := quotaCalls.Load(); calls != 2 {
+		t.Errorf("expected 1 quotas call per scrape, got %d", calls)
+	}
+}
+
+func TestCollectorBootTimesAccumulate(t *testing.T) {
+	var starts atomic.Int32
+	starts.Store(1)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instances":
+			fmt.Fprint(w, `{"data":{"instances":[{"uuid":"`+uuid1+`","name":"a","image":"nginx:latest","state":"running"}]}}`)
+
+		case "/instances/metrics":
+			fmt.Fprintf(w, `{"data":{"instances":[{"uuid":"`+uuid1+`","name":"a","state":"running","boot_time_us":2000,"start_count":%d}]}}`, starts.Load())
+
+		case "/users/quotas":
+			fmt.Fprint(w, `{"data":{"quotas":[{}]}}`)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	// Without explicit metros, the collector scrapes the metro of the client.
+	collector := exporter.NewCollector(
+		kraftcloud.NewClient(kraftcloud.WithToken("token"), kraftcloud.WithDefaultMetro(srv.URL)),
+		exporter.WithImageQuotas(false),
+		exporter.WithBootTimeBuckets(.005),
+		exporter.WithErrorHandler(func(metro string, err error) {
+			t.Errorf("unexpected error in metro %s: %v", metro, err)
+		}),
+	)
+
+	expected := func(count int) string {
+		return strings.ReplaceAll(fmt.Sprintf(`
+# HELP kraftcloud_instance_boot_time_seconds Time from start of the instance to finish booting of Unikraft.
+# TYPE kraftcloud_instance_boot_time_seconds histogram
+kraftcloud_instance_boot_time_seconds_bucket{image="nginx:latest",metro="METRO",service_group="",le="0.005"} %[1]d
+kraftcloud_instance_boot_time_seconds_bucket{image="nginx:latest",metro="METRO",service_group="",le="+Inf"} %[1]d
+kraftcloud_instance_boot_time_seconds_sum{image="nginx:latest",metro="METRO",service_group=""} %[2]g
+kraftcloud_instance_boot_time_seconds_count{image="nginx:latest",metro="METRO",service_group=""} %[1]d
+# HELP kraftcloud_up Whether the last scrape of the metro was successful.
+# TYPE kraftcloud_up gauge
+kraftcloud_up{metro="METRO"} 1
+`, count, float64(count)*.002), "METRO", srv.URL)
+	}
+
+	for _, step := range []struct {
+		starts int32
+		count  int
+	}{
+		{starts: 1, count: 1},
+		{starts: 1, count: 1},
+		{starts: 2, count: 2},
+	} {
+		starts.Store(step.starts)
+
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected(step.count)),
+			"kraftcloud_instance_boot_time_seconds",
+			"kraftcloud_up",
+		); err != nil {
+			t.Fatalf("start count %d: %v", step.starts, err)
+		}
+	}
+}
+
+func TestCollectorPartialFailure(t *testing.T) {
+	var listCalls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/instances":
+			listCalls.Add(1)
+			fmt.Fprint(w, `{"data":{"instances":[`+
+				`{"uuid":"`+uuid1+`","name":"a","image":"nginx:latest","state":"running"},`+
+				`{"uuid":"`+uuid2+`","name":"b","image":"nginx:latest","state":"running"}`+
+				`]}}`)
+
+		case "/instances/metrics":
+			fmt.Fprint(w, `{"status":"partial_success","message":"one instance failed","data":{"instances":[`+
+				`{"uuid":"`+uuid1+`","name":"a","state":"running","rss_bytes":1024},`+
+				`{"status":"error","uuid":"`+uuid2+`","message":"No instance with UUID","error":8}`+
+				`]}}`)
+
+		case "/users/quotas":
+			fmt.Fprint(w, `{"data":{"quotas":[{}]}}`)
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	var errs atomic.Int32
+
+	collector := exporter.NewCollector(
+		kraftcloud.NewClient(kraftcloud.WithToken("token")),
+		exporter.WithMetros(srv.URL),
+		exporter.WithImageQuotas(false),
+		exporter.WithErrorHandler(func(metro string, err error) {
+			if metro != srv.URL || !strings.Contains(err.Error(), uuid2) {
+				t.Errorf("unexpected error in metro %s: %v", metro, err)
+			}
+			errs.Add(1)
+		}),
+	)
+
+	expected := strings.ReplaceAll(`
+# HELP kraftcloud_instance_rss_bytes Resident memory size of the instance.
+# TYPE kraftcloud_instance_rss_bytes gauge
+kraftcloud_instance_rss_bytes{image="nginx:latest",metro="METRO",name="a",service_group="",state="running",uuid="`+uuid1+`"} 1024
+# HELP kraftcloud_scrape_errors Number of instances which could not be listed or scraped during the last scrape of the metro.
+# TYPE kraftcloud_scrape_errors gauge
+kraftcloud_scrape_errors{metro="METRO"} 1
+# HELP kraftcloud_up Whether the last scrape of the metro was successful.
+# TYPE kraftcloud_up gauge
+kraftcloud_up{metro="METRO"} 1
+`, "METRO", srv.URL)
+
+	// Failed instances invalidate the inventory, so that every scrape lists
+	// the instances again.
+	for range 2 {
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"kraftcloud_instance_rss_bytes",
+			"kraftcloud_scrape_errors",
+			"kraftcloud_up",
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := errs.Load(); n != 2 {
+		t.Errorf("expected the failed instance to be reported on every scrape, got %d errors", n)
+	}
+	if calls := listCalls.Load(); calls != 2 {
+		t.Errorf("expected the instances to be listed on every scrape, got %d", calls)
+	}
+}
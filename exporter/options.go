@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package exporter
+
+import "time"
+
+// CollectorOption is an option function used during initialization of a
+// Collector.
+type CollectorOption func(*Collector)
+
+// WithMetros sets the metros which are queried on every scrape.  When unset,
+// only the default metro is queried.
+func WithMetros(metros ...string) CollectorOption {
+	return func(c *Collector) {
+		c.metros = metros
+	}
+}
+
+// WithImageQuotas enables or disables the collection of image storage quotas
+// from the registry, which costs one additional API call per scrape.  Enabled
+// by default.
+func WithImageQuotas(enabled bool) CollectorOption {
+	return func(c *Collector) {
+		c.imageQuotas = enabled
+	}
+}
+
+// WithScrapeTimeout sets the maximum duration of a single scrape.
+func WithScrapeTimeout(timeout time.Duration) CollectorOption {
+	return func(c *Collector) {
+		c.timeout = timeout
+	}
+}
+
+// WithRefreshInterval sets the interval at which the inventory of instances of
+// every metro is refreshed.  Scrapes in between only retrieve the metrics of
+// the known instances and the user quotas.  Defaults to
+// DefaultRefreshInterval if unset or not positive.
+func WithRefreshInterval(interval time.Duration) CollectorOption {
+	return func(c *Collector) {
+		c.refreshInterval = interval
+	}
+}
+
+// WithBootTimeBuckets overwrites the buckets, in seconds, of the boot and
+// network time histograms.
+func WithBootTimeBuckets(buckets ...float64) CollectorOption {
+	return func(c *Collector) {
+		c.buckets = buckets
+	}
+}
+
+// WithErrorHandler sets a function which is called with every error that
+// occurs during a scrape, including the errors of individual instances.  Errors
+// are otherwise only reflected by the kraftcloud_up and kraftcloud_scrape_errors
+// metrics.
+func WithErrorHandler(fn func(metro string, err error)) CollectorOption {
+	return func(c *Collector) {
+		c.onError = fn
+	}
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package kraftcloud
+
+import kcclient "sdk.kraft.cloud/client"
+
+// Ref is a typed reference to a resource, either by UUID or by name.  Services
+// accept Refs wherever they take identifiers.
+type Ref = kcclient.Ref
+
+// ByUUID returns a reference to the resource with the given UUID.
+func ByUUID(id string) Ref {
+	return kcclient.ByUUID(id)
+}
+
+// ByName returns a reference to the resource with the given name, even if the
+// name has the shape of a UUID.
+func ByName(name string) Ref {
+	return kcclient.ByName(name)
+}
+
+// ParseRef returns a reference to the resource with the given identifier,
+// which refers to a UUID if it has the shape of one, and to a name otherwise.
+func ParseRef(id string) Ref {
+	return kcclient.ParseRef(id)
+}
@@ -53,6 +53,11 @@ func (c *client) WithTimeout(to time.Duration) InstancesService {
 	return ccpy
 }
 
+// Metro returns the metro to which requests are sent.
+func (c *client) Metro() string {
+	return c.request.Metro()
+}
+
 // clone returns a shallow copy of c.
 func (c *client) clone() *client {
 	ccpy := *c
@@ -16,6 +16,7 @@ import (
 	"testing"
 
 	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
 )
 
 const (
@@ -47,7 +48,7 @@ func TestClientThreadSafety(t *testing.T) {
 			go func(uuid string) {
 				defer wg.Done()
 				for i := 0; i < requests; i++ {
-					resp, err := cli.Get(ctx, uuid)
+					resp, err := cli.Get(ctx, kcclient.ByUUID(uuid))
 					if err != nil {
 						select {
 						case <-ctx.Done():
@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package crashloop
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+// Reason describes which condition flagged an instance.
+type Reason string
+
+const (
+	// ReasonRestarts indicates that the instance was restarted too often
+	// within the configured window.
+	ReasonRestarts Reason = "restarts"
+
+	// ReasonRepeatedStops indicates that the instance repeatedly stopped with
+	// the same failing stop code.
+	ReasonRepeatedStops Reason = "repeated_stops"
+)
+
+// Stop is a stop of an instance observed by the detector.
+type Stop struct {
+	// StoppedAt is the time of the stop as reported by the API.
+	StoppedAt kcclient.Timestamp `json:"stopped_at"`
+
+	// StopCode is the raw stop code of the stop.
+	StopCode uint `json:"stop_code"`
+
+	// Diagnosis is the decoded stop.
+	Diagnosis instances.StopDiagnosis `json:"diagnosis"`
+}
+
+// Event is emitted when an instance is detected to be in a crash loop.
+type Event struct {
+	// UUID of the flagged instance.
+	UUID string `json:"uuid"`
+
+	// Name of the flagged instance.
+	Name string `json:"name"`
+
+	// Reason is the condition which flagged the instance.
+	Reason Reason `json:"reason"`
+
+	// Time at which the instance was flagged.
+	Time time.Time `json:"time"`
+
+	// Restarts is the number of restarts observed within the window.
+	Restarts int `json:"restarts"`
+
+	// RestartPolicy is the restart policy of the instance.
+	RestartPolicy instances.RestartPolicy `json:"restart_policy"`
+
+	// NextRestartAt is the time of the next scheduled restart, if any.
+	NextRestartAt kcclient.Timestamp `json:"next_restart_at,omitzero"`
+
+	// Stops are the most recent stops of the instance, oldest first.
+	Stops []Stop `json:"stops"`
+
+	// Stopped is true if the detector stopped the instance.
+	Stopped bool `json:"stopped"`
+
+	// StopError is set if the detector failed to stop the instance.
+	StopError error `json:"-"`
+}
+
+// Detector watches instances and flags those which are stuck in a restart or
+// crash loop.  An instance is flagged once when it enters a loop and can only
+// be flagged again after the loop condition has cleared.
+type Detector struct {
+	client instances.InstancesService
+
+	ids           []kcclient.Ref
+	restarts      int
+	window        time.Duration
+	repeatedStops int
+	autoStop      bool
+	interval      time.Duration
+	history       int
+	now           func() time.Time
+	onError       func(error)
+
+	mu     sync.Mutex
+	states map[string]*instanceState
+}
+
+// instanceState is what the detector remembers about an instance between two
+// polls.
+type instanceState struct {
+	restartCount uint
+	stoppedAt    string
+	restarts     []time.Time
+	stops        []Stop
+	flagged      bool
+}
+
+// NewDetector instantiates a new Detector which polls instances through the
+// given client.
+func NewDetector(client instances.InstancesService, dopts ...DetectorOption) *Detector {
+	d := &Detector{
+		client:        client,
+		restarts:      DefaultRestarts,
+		window:        DefaultWindow,
+		repeatedStops: DefaultRepeatedStops,
+		interval:      DefaultInterval,
+		history:       DefaultHistory,
+		now:           time.Now,
+		states:        make(map[string]*instanceState),
+	}
+
+	for _, opt := range dopts {
+		opt(d)
+	}
+
+	if d.interval <= 0 {
+		d.interval = DefaultInterval
+	}
+
+	// Repeated stops can only be detected if enough stops are kept.
+	if d.history < max(d.repeatedStops, 1) {
+		d.history = max(d.repeatedStops, 1)
+	}
+
+	return d
+}
+
+// Run polls the instances at the configured interval and sends an event for
+// every newly flagged instance.  Failed polls are passed to the error handler,
+// if any, and retried on the next tick.  It blocks until the context is
+// cancelled.
+func (d *Detector) Run(ctx context.Context, events chan<- Event) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		evs, err := d.Check(ctx)
+		if err != nil && ctx.Err() == nil && d.onError != nil {
+			d.onError(err)
+		}
+
+		for _, ev := range evs {
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Check polls the instances once and returns an event for every newly flagged
+// instance.
+func (d *Detector) Check(ctx context.Context) ([]Event, error) {
+	var (
+		resp *kcclient.ServiceResponse[instances.GetResponseItem]
+		err  error
+	)
+
+	if len(d.ids) > 0 {
+		resp, err = d.client.Get(ctx, d.ids...)
+	} else {
+		resp, err = d.client.List(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting instances: %w", err)
+	}
+
+	// Entries which failed are skipped below, unless all of them failed.
+	items, err := resp.AllOrErr()
+	if err != nil && (len(items) == 0 || resp.Status == "error") {
+		return nil, fmt.Errorf("getting instances: %w", err)
+	}
+
+	d.mu.Lock()
+
+	now := d.now()
+	seen := make(map[string]struct{}, len(items))
+
+	var events []Event
+
+	for i := range items {
+		item := &items[i]
+		if item.Error != nil || item.UUID == "" {
+			continue
+		}
+
+		seen[item.UUID] = struct{}{}
+
+		if ev, ok := d.observe(now, item); ok {
+			events = append(events, ev)
+		}
+	}
+
+	// Forget about instances which no longer exist.
+	if len(d.ids) == 0 {
+		for uuid := range d.states {
+			if _, ok := seen[uuid]; !ok {
+				delete(d.states, uuid)
+			}
+		}
+	}
+
+	d.mu.Unlock()
+
+	// Stop the flagged instances without holding the lock, so that concurrent
+	// checks are not blocked by the API.
+	if d.autoStop {
+		for i := range events {
+			if _, err := d.client.Stop(ctx, 0, true, kcclient.ByUUID(events[i].UUID)); err != nil {
+				events[i].StopError = fmt.Errorf("stopping instance: %w", err)
+			} else {
+				events[i].Stopped = true
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// observe updates the state of the instance with the latest observation and
+// returns an event if the instance has just entered a loop.
+func (d *Detector) observe(now time.Time, item *instances.GetResponseItem) (Event, bool) {
+	st, ok := d.states[item.UUID]
+	if !ok {
+		// The first observation only establishes a baseline, since restarts
+		// which happened before cannot be placed in time.
+		st = &instanceState{
+			restartCount: item.RestartCount,
+		}
+		d.states[item.UUID] = st
+	}
+
+	if item.RestartCount > st.restartCount {
+		for n := item.RestartCount - st.restartCount; n > 0; n-- {
+			st.restarts = append(st.restarts, now)
+		}
+	}
+	st.restartCount = item.RestartCount
+
+	if item.StopCode != nil && !item.StoppedAt.IsZero() && item.StoppedAt.String() != st.stoppedAt {
+		st.stoppedAt = item.StoppedAt.String()
+		st.stops = append(st.stops, Stop{
+			StoppedAt: item.StoppedAt,
+			StopCode:  *item.StopCode,
+			Diagnosis: item.Diagnose(),
+		})
+		if len(st.stops) > d.history {
+			st.stops = st.stops[len(st.stops)-d.history:]
+		}
+	}
+
+	// Drop restarts which fell out of the window.
+	cutoff := now.Add(-d.window)
+	i := 0
+	for i < len(st.restarts) && !st.restarts[i].After(cutoff) {
+		i++
+	}
+	st.restarts = st.restarts[i:]
+
+	var reason Reason
+	switch {
+	case d.restarts > 0 && len(st.restarts) >= d.restarts:
+		reason = ReasonRestarts
+	case d.repeatedStops > 0 && repeatedFailure(st.stops, d.repeatedStops):
+		reason = ReasonRepeatedStops
+	}
+
+	if reason == "" {
+		st.flagged = false
+		return Event{}, false
+	}
+
+	if st.flagged {
+		return Event{}, false
+	}
+	st.flagged = true
+
+	ev := Event{
+		UUID:          item.UUID,
+		Name:          item.Name,
+		Reason:        reason,
+		Time:          now,
+		Restarts:      len(st.restarts),
+		RestartPolicy: item.RestartPolicy,
+		Stops:         append([]Stop(nil), st.stops...),
+	}
+	if item.Restart != nil {
+		ev.NextRestartAt = item.Restart.NextAt
+	}
+
+	return ev, true
+}
+
+// repeatedFailure returns whether the last n stops were caused by the same
+// non-OK stop code.
+func repeatedFailure(stops []Stop, n int) bool {
+	if len(stops) < n {
+		return false
+	}
+
+	last := stops[len(stops)-n:]
+	for _, stop := range last {
+		if stop.Diagnosis.Reason == instances.StopCodeReasonOK || stop.StopCode != last[0].StopCode {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package crashloop_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/instances/crashloop"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+const uuid1 = "00000000-0000-0000-0000-000000000001"
+
+// fakeInstance is a single instance whose restart count and last stop can be
+// changed between polls.
+type fakeInstance struct {
+	restarts  int
+	stoppedAt string
+	stopCode  uint
+	stops     int
+}
+
+func (f *fakeInstance) restart(stoppedAt string, stopCode uint) {
+	f.restarts++
+	f.stoppedAt = stoppedAt
+	f.stopCode = stopCode
+}
+
+// serve registers the instance endpoints with the fake API.
+func (f *fakeInstance) serve(api *fakeapi.Server) {
+	api.Handle(http.MethodGet, "/instances", "instances", func(r *fakeapi.Request) []string {
+		return []string{fmt.Sprintf(`{"uuid":%q,"name":"app","state":"running",`+
+			`"restart_count":%d,"stopped_at":%q,"stop_code":%d,"stop_reason":1}`,
+			uuid1, f.restarts, f.stoppedAt, f.stopCode)}
+	})
+
+	api.Handle(http.MethodPut, "/instances/stop", "instances", func(r *fakeapi.Request) []string {
+		f.stops++
+		return []string{fmt.Sprintf(`{"uuid":%q,"state":"stopped"}`, uuid1)}
+	})
+}
+
+func TestDetectorRestarts(t *testing.T) {
+	fake := &fakeInstance{}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	detector := crashloop.NewDetector(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		crashloop.WithRestarts(3, time.Minute),
+		crashloop.WithRepeatedStops(0),
+		crashloop.WithAutoStop(true),
+		crashloop.WithClock(func() time.Time { return now }),
+	)
+
+	ctx := context.Background()
+
+	check := func() []crashloop.Event {
+		t.Helper()
+		events, err := detector.Check(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+
+	// Establish the baseline.
+	if events := check(); len(events) != 0 {
+		t.Fatalf("expected no events on first poll, got %d", len(events))
+	}
+
+	// Two restarts, then a long pause which moves them out of the window.
+	for i := 0; i < 2; i++ {
+		now = now.Add(10 * time.Second)
+		api.Do(func() { fake.restart(now.Format(time.RFC3339), 0x000105) })
+		if events := check(); len(events) != 0 {
+			t.Fatalf("expected no events below threshold, got %d", len(events))
+		}
+	}
+
+	now = now.Add(2 * time.Minute)
+	api.Do(func() { fake.restart(now.Format(time.RFC3339), 0x000105) })
+	if events := check(); len(events) != 0 {
+		t.Fatalf("expected old restarts to fall out of the window, got %d events", len(events))
+	}
+
+	// Three restarts in quick succession flag the instance exactly once.
+	for i := 0; i < 2; i++ {
+		now = now.Add(5 * time.Second)
+		api.Do(func() { fake.restart(now.Format(time.RFC3339), 0x000105) })
+	}
+
+	events := check()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if ev := events[0]; ev.Reason != crashloop.ReasonRestarts || ev.Restarts != 3 || !ev.Stopped {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+	if len(events[0].Stops) == 0 {
+		t.Fatal("expected stop diagnoses to be attached to the event")
+	}
+
+	now = now.Add(time.Second)
+	if events := check(); len(events) != 0 {
+		t.Fatalf("expected instance to be flagged only once, got %d events", len(events))
+	}
+
+	api.Do(func() {
+		if fake.stops != 1 {
+			t.Errorf("expected instance to be stopped once, got %d", fake.stops)
+		}
+	})
+}
+
+func TestDetectorRepeatedStops(t *testing.T) {
+	fake := &fakeInstance{}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	detector := crashloop.NewDetector(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		crashloop.WithRestarts(0, time.Minute),
+		crashloop.WithRepeatedStops(3),
+		// Fewer stops than needed to detect a crash loop are never kept.
+		crashloop.WithHistory(-1),
+		crashloop.WithClock(func() time.Time { return now }),
+	)
+
+	ctx := context.Background()
+
+	check := func() []crashloop.Event {
+		t.Helper()
+		events, err := detector.Check(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return events
+	}
+
+	check()
+
+	// A successful stop in between resets the streak.
+	for _, code := range []uint{0x000105, 0x000100, 0x000105, 0x000105} {
+		now = now.Add(10 * time.Second)
+		api.Do(func() { fake.restart(now.Format(time.RFC3339), code) })
+		if events := check(); len(events) != 0 {
+			t.Fatalf("expected no events below threshold, got %+v", events)
+		}
+	}
+
+	now = now.Add(10 * time.Second)
+	api.Do(func() { fake.restart(now.Format(time.RFC3339), 0x000105) })
+
+	events := check()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if ev := events[0]; ev.Reason != crashloop.ReasonRepeatedStops || len(ev.Stops) != 3 || ev.Stopped {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+	for _, stop := range events[0].Stops {
+		if stop.StopCode != 0x000105 {
+			t.Errorf("expected only the failed stops to be attached, got %+v", events[0].Stops)
+			break
+		}
+	}
+}
+
+func TestDetectorRunRetries(t *testing.T) {
+	fake := &fakeInstance{}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	var polls int
+	api.Handle(http.MethodGet, "/instances", "instances", func(r *fakeapi.Request) []string {
+		polls++
+		if polls == 1 {
+			return []string{`{"status":"error","message":"timed out","error":12}`}
+		}
+		return []string{fmt.Sprintf(`{"uuid":%q,"name":"app","state":"running","restart_count":%d}`, uuid1, polls)}
+	})
+
+	errs := make(chan error, 1)
+
+	detector := crashloop.NewDetector(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		crashloop.WithRestarts(2, time.Minute),
+		// Non-positive intervals fall back to the default.
+		crashloop.WithInterval(0),
+		crashloop.WithErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events := make(chan crashloop.Event)
+	done := make(chan error, 1)
+	go func() { done <- detector.Run(ctx, events) }()
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("expected the failed poll to be reported")
+		}
+	case err := <-done:
+		t.Fatalf("expected Run to keep polling after an error, got %v", err)
+	case <-ctx.Done():
+		t.Fatal("expected the failed poll to be reported")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled && err != context.DeadlineExceeded {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+}
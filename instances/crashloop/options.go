@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package crashloop
+
+import (
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+const (
+	// DefaultRestarts is the default number of restarts within the window
+	// after which an instance is considered to be in a restart loop.
+	DefaultRestarts = 3
+
+	// DefaultWindow is the default window in which restarts are counted.
+	DefaultWindow = 5 * time.Minute
+
+	// DefaultRepeatedStops is the default number of consecutive identical
+	// failed stops after which an instance is considered to be in a crash loop.
+	DefaultRepeatedStops = 3
+
+	// DefaultInterval is the default interval at which instances are polled.
+	DefaultInterval = 10 * time.Second
+
+	// DefaultHistory is the default number of stop diagnoses which are kept
+	// per instance.
+	DefaultHistory = 10
+)
+
+// DetectorOption is an option function used during initialization of a
+// Detector.
+type DetectorOption func(*Detector)
+
+// WithInstances restricts the detector to the referenced instances.  By
+// default, all instances are watched.
+func WithInstances(ids ...kcclient.Ref) DetectorOption {
+	return func(d *Detector) {
+		d.ids = ids
+	}
+}
+
+// WithRestarts flags instances which are restarted n or more times within
+// the given window.
+func WithRestarts(n int, window time.Duration) DetectorOption {
+	return func(d *Detector) {
+		d.restarts = n
+		d.window = window
+	}
+}
+
+// WithRepeatedStops flags instances whose last n stops failed with the same
+// stop code.
+func WithRepeatedStops(n int) DetectorOption {
+	return func(d *Detector) {
+		d.repeatedStops = n
+	}
+}
+
+// WithAutoStop makes the detector force stop flagged instances to save quota.
+func WithAutoStop(enabled bool) DetectorOption {
+	return func(d *Detector) {
+		d.autoStop = enabled
+	}
+}
+
+// WithInterval sets the interval at which Run polls the instances.
+// Non-positive intervals are replaced by DefaultInterval.
+func WithInterval(interval time.Duration) DetectorOption {
+	return func(d *Detector) {
+		d.interval = interval
+	}
+}
+
+// WithHistory sets the number of stop diagnoses which are kept per instance
+// and attached to events.  At least as many stops as set by
+// WithRepeatedStops are always kept.
+func WithHistory(n int) DetectorOption {
+	return func(d *Detector) {
+		d.history = n
+	}
+}
+
+// WithClock overwrites the function used to determine the current time.
+func WithClock(now func() time.Time) DetectorOption {
+	return func(d *Detector) {
+		d.now = now
+	}
+}
+
+// WithErrorHandler sets a function which is called with the error of every
+// failed poll of Run.  Run keeps polling after an error.
+func WithErrorHandler(fn func(error)) DetectorOption {
+	return func(d *Detector) {
+		d.onError = fn
+	}
+}
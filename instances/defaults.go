@@ -8,3 +8,7 @@ package instances
 // DefaultAutoStart is the default autostart value - whether the instance will
 // start immediately after creation
 const DefaultAutoStart = true
+
+// DefaultWaitTimeoutMs is the default timeout used by utility methods when
+// waiting for an instance to reach a desired state.
+const DefaultWaitTimeoutMs = 60000
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package drain gracefully stops instances on KraftCloud in batches, waiting
+// for in-flight connections and requests to complete before escalating to a
+// forced stop.
+package drain
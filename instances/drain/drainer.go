@@ -0,0 +1,329 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package drain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+// ErrSkipped is the error of instances which were not drained because a
+// previous batch failed.
+var ErrSkipped = errors.New("skipped after a previous batch failed")
+
+// Progress is a snapshot of a draining instance.
+type Progress struct {
+	// UUID of the instance.
+	UUID string `json:"uuid"`
+
+	// Name of the instance.
+	Name string `json:"name"`
+
+	// State of the instance.
+	State instances.InstanceState `json:"state"`
+
+	// Connections is the number of established inbound connections.
+	Connections uint64 `json:"connections"`
+
+	// Requests is the number of in-flight HTTP requests.
+	Requests uint64 `json:"requests"`
+
+	// Queued is the number of queued connections and requests.
+	Queued uint64 `json:"queued"`
+
+	// Elapsed is the time since the drain of the instance started.
+	Elapsed time.Duration `json:"elapsed"`
+
+	// Forced is true if the instance has been forcefully stopped.
+	Forced bool `json:"forced"`
+}
+
+// Transition is a state change of a draining instance.
+type Transition struct {
+	// State is the state the instance entered.
+	State instances.InstanceState `json:"state"`
+
+	// Elapsed is the time since the drain of the instance started.
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// Result is the outcome of draining a single instance.
+type Result struct {
+	// ID is the UUID or name of the instance as passed to Drain.
+	ID string `json:"id"`
+
+	// UUID of the instance.
+	UUID string `json:"uuid"`
+
+	// Name of the instance.
+	Name string `json:"name"`
+
+	// Transitions are the observed state changes of the instance.
+	Transitions []Transition `json:"transitions"`
+
+	// Forced is true if the instance had to be forcefully stopped.
+	Forced bool `json:"forced"`
+
+	// Duration is the time it took for the instance to stop.
+	Duration time.Duration `json:"duration"`
+
+	// Err is set if the instance could not be stopped.
+	Err error `json:"-"`
+
+	ref kcclient.Ref
+}
+
+// Drainer gracefully stops instances in batches.
+type Drainer struct {
+	client instances.InstancesService
+
+	batchSize    int
+	drainTimeout time.Duration
+	forceAfter   time.Duration
+	interval     time.Duration
+	progress     func(Progress)
+}
+
+// NewDrainer instantiates a new Drainer which stops instances through the
+// given client.
+func NewDrainer(client instances.InstancesService, dopts ...DrainerOption) *Drainer {
+	d := &Drainer{
+		client:       client,
+		batchSize:    DefaultBatchSize,
+		drainTimeout: DefaultDrainTimeout,
+		forceAfter:   DefaultForceAfter,
+		interval:     DefaultInterval,
+	}
+
+	for _, opt := range dopts {
+		opt(d)
+	}
+
+	if d.forceAfter <= 0 {
+		d.forceAfter = DefaultForceAfter
+	}
+	if d.interval <= 0 {
+		d.interval = DefaultInterval
+	}
+
+	return d
+}
+
+// Drain stops the referenced instances in batches and
+// waits for each batch to stop before starting the next.  Instances which do
+// not stop within the configured deadline are forcefully stopped.  If a batch
+// fails, the remaining instances are skipped.  The returned error combines the
+// errors of all instances which could not be stopped.
+func (d *Drainer) Drain(ctx context.Context, ids ...kcclient.Ref) ([]Result, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("requires at least one identifier")
+	}
+
+	batchSize := d.batchSize
+	if batchSize <= 0 {
+		batchSize = len(ids)
+	}
+
+	results := make([]Result, len(ids))
+	for i, id := range ids {
+		results[i].ID = id.String()
+		results[i].ref = id
+	}
+
+	failed := false
+	for start := 0; start < len(results); start += batchSize {
+		batch := results[start:min(start+batchSize, len(results))]
+
+		if failed {
+			for i := range batch {
+				batch[i].Err = ErrSkipped
+			}
+			continue
+		}
+
+		d.drainBatch(ctx, batch)
+
+		for _, res := range batch {
+			if res.Err != nil {
+				failed = true
+			}
+		}
+	}
+
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("draining instance '%s': %w", res.ID, res.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// drainBatch stops all instances of the batch and records the outcome in
+// place.
+func (d *Drainer) drainBatch(ctx context.Context, batch []Result) {
+	ids := make([]kcclient.Ref, 0, len(batch))
+	for _, res := range batch {
+		ids = append(ids, res.ref)
+	}
+
+	start := time.Now()
+
+	resp, err := d.client.Stop(ctx, int(d.drainTimeout.Milliseconds()), false, ids...)
+	if err != nil {
+		for i := range batch {
+			batch[i].Err = fmt.Errorf("stopping: %w", err)
+		}
+		return
+	}
+
+	if len(resp.Data.Entries) != len(batch) {
+		for i := range batch {
+			batch[i].Err = fmt.Errorf("expected %d instances in response, got %d", len(batch), len(resp.Data.Entries))
+		}
+		return
+	}
+
+	// Indices of the instances in the batch which have not stopped yet.
+	pending := make(map[string]int, len(batch))
+
+	for i, item := range resp.Data.Entries {
+		if item.Error != nil {
+			batch[i].Err = fmt.Errorf("stopping: %s (code=%d)", item.Message, *item.Error)
+			continue
+		}
+
+		batch[i].UUID = item.UUID
+		batch[i].Name = item.Name
+		batch[i].Transitions = []Transition{{State: instances.InstanceState(item.State)}}
+
+		if instances.InstanceState(item.State) == instances.InstanceStateStopped {
+			continue
+		}
+		pending[item.UUID] = i
+	}
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	forced := false
+
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			for _, i := range pending {
+				batch[i].Err = fmt.Errorf("waiting for instance to stop: %w", ctx.Err())
+			}
+			return
+		case <-ticker.C:
+		}
+
+		elapsed := time.Since(start)
+
+		uuids := make([]string, 0, len(pending))
+		for uuid := range pending {
+			uuids = append(uuids, uuid)
+		}
+
+		if !forced && elapsed >= d.forceAfter {
+			forced = true
+
+			// Errors are tolerated, since the instances may have stopped in
+			// the meantime.  The next poll reveals whether they did.
+			if resp, err := d.client.Stop(ctx, 0, true, kcclient.UUIDs(uuids...)...); err == nil {
+				for _, item := range resp.Data.Entries {
+					if i, ok := pending[item.UUID]; ok && item.Error == nil {
+						batch[i].Forced = true
+					}
+				}
+			}
+		}
+
+		metrics, err := d.client.Metrics(ctx, kcclient.UUIDs(uuids...)...)
+		if err != nil {
+			// A transient failure only delays the next observation.
+			continue
+		}
+
+		// Instances without metrics, e.g. because they stopped in the
+		// meantime, are looked up instead.
+		var unobserved []string
+
+		for _, item := range metrics.Data.Entries {
+			i, ok := pending[item.UUID]
+			if !ok {
+				continue
+			}
+			if item.Error != nil {
+				unobserved = append(unobserved, item.UUID)
+				continue
+			}
+			res := &batch[i]
+
+			d.observe(res, item.State, elapsed)
+
+			if d.progress != nil {
+				d.progress(Progress{
+					UUID:        item.UUID,
+					Name:        item.Name,
+					State:       item.State,
+					Connections: item.Connections,
+					Requests:    item.Requests,
+					Queued:      item.Queued,
+					Elapsed:     elapsed,
+					Forced:      res.Forced,
+				})
+			}
+
+			if item.State == instances.InstanceStateStopped {
+				delete(pending, item.UUID)
+			}
+		}
+
+		if len(unobserved) == 0 {
+			continue
+		}
+
+		resp, err := d.client.Get(ctx, kcclient.UUIDs(unobserved...)...)
+		if err != nil {
+			continue
+		}
+
+		for _, item := range resp.Data.Entries {
+			i, ok := pending[item.UUID]
+			if !ok || item.Error != nil {
+				continue
+			}
+
+			state := instances.InstanceState(item.State)
+			d.observe(&batch[i], state, elapsed)
+
+			if state == instances.InstanceStateStopped {
+				delete(pending, item.UUID)
+			}
+		}
+	}
+}
+
+// observe records the state of a draining instance.
+func (d *Drainer) observe(res *Result, state instances.InstanceState, elapsed time.Duration) {
+	if last := res.Transitions[len(res.Transitions)-1]; last.State != state {
+		res.Transitions = append(res.Transitions, Transition{
+			State:   state,
+			Elapsed: elapsed,
+		})
+	}
+
+	if state == instances.InstanceStateStopped {
+		res.Duration = elapsed
+	}
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package drain_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/instances/drain"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+const (
+	uuid1 = "00000000-0000-0000-0000-000000000001"
+	uuid2 = "00000000-0000-0000-0000-000000000002"
+)
+
+type snapshot struct {
+	state string
+	conns int
+}
+
+// fakeInstances are instances which move through a scripted sequence of
+// states on every metrics request.  A zero snapshot has no metrics, as for an
+// instance which stopped, and only reveals the state through a get.
+// Forcefully stopped instances stop immediately.
+type fakeInstances struct {
+	names   map[string]string
+	scripts map[string][]snapshot
+	stopped map[string]bool
+	forced  []string
+}
+
+// serve registers the instance endpoints with the fake API.
+func (f *fakeInstances) serve(api *fakeapi.Server) {
+	api.Handle(http.MethodPut, "/instances/stop", "instances", func(r *fakeapi.Request) []string {
+		var entries []string
+		for i, item := range r.Items {
+			uuid := r.String(i, "uuid")
+			if force, _ := item["force"].(bool); force {
+				f.forced = append(f.forced, f.names[uuid])
+				f.stopped[uuid] = true
+			}
+			entries = append(entries, fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q,"state":"draining"}`, uuid, f.names[uuid]))
+		}
+		return entries
+	})
+
+	api.Handle(http.MethodGet, "/instances/metrics", "instances", func(r *fakeapi.Request) []string {
+		var entries []string
+		for i := range r.Items {
+			uuid := r.String(i, "uuid")
+			snap := snapshot{state: "stopped"}
+			if !f.stopped[uuid] {
+				script := f.scripts[uuid]
+				snap = script[0]
+				if len(script) > 1 {
+					f.scripts[uuid] = script[1:]
+				}
+			}
+			if snap.state == "" {
+				entries = append(entries, fmt.Sprintf(`{"status":"error","uuid":%q,"message":"no metrics","error":11}`, uuid))
+				continue
+			}
+			entries = append(entries, fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q,"state":%q,"nconns":%d}`,
+				uuid, f.names[uuid], snap.state, snap.conns))
+		}
+		return entries
+	})
+
+	api.Handle(http.MethodGet, "/instances", "instances", func(r *fakeapi.Request) []string {
+		var entries []string
+		for i := range r.Items {
+			uuid := r.String(i, "uuid")
+			entries = append(entries, fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q,"state":"stopped"}`, uuid, f.names[uuid]))
+		}
+		return entries
+	})
+}
+
+func TestDrain(t *testing.T) {
+	fake := &fakeInstances{
+		names: map[string]string{uuid1: "a", uuid2: "b"},
+		scripts: map[string][]snapshot{
+			uuid1: {{"draining", 2}, {"draining", 1}, {"stopping", 0}, {"stopped", 0}},
+			uuid2: {{"draining", 5}},
+		},
+		stopped: map[string]bool{},
+	}
+
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	var (
+		mu    sync.Mutex
+		conns = map[string][]uint64{}
+	)
+
+	drainer := drain.NewDrainer(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		drain.WithBatchSize(1),
+		drain.WithInterval(5*time.Millisecond),
+		drain.WithForceAfter(100*time.Millisecond),
+		drain.WithProgress(func(p drain.Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			conns[p.Name] = append(conns[p.Name], p.Connections)
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := drainer.Drain(ctx, kcclient.ByUUID(uuid1), kcclient.ByUUID(uuid2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := results[0], results[1]
+
+	var states []instances.InstanceState
+	for _, tr := range a.Transitions {
+		states = append(states, tr.State)
+	}
+	if fmt.Sprint(states) != "[draining stopping stopped]" {
+		t.Errorf("expected instance 'a' to drain, stop and be stopped, got %v", states)
+	}
+	if a.Forced {
+		t.Error("expected instance 'a' to stop gracefully")
+	}
+	if fmt.Sprint(conns["a"]) != "[2 1 0 0]" {
+		t.Errorf("expected connections of instance 'a' to fall, got %v", conns["a"])
+	}
+
+	if !b.Forced {
+		t.Error("expected instance 'b' to be forcefully stopped")
+	}
+	if b.Duration < 100*time.Millisecond {
+		t.Errorf("expected instance 'b' to stop after the deadline, got %s", b.Duration)
+	}
+
+	api.Do(func() {
+		if fmt.Sprint(fake.forced) != "[b]" {
+			t.Errorf("expected only instance 'b' to be forcefully stopped, got %v", fake.forced)
+		}
+	})
+}
+
+func TestDrainWithoutMetrics(t *testing.T) {
+	fake := &fakeInstances{
+		names: map[string]string{uuid1: "a"},
+		scripts: map[string][]snapshot{
+			uuid1: {{"draining", 1}, {}},
+		},
+		stopped: map[string]bool{},
+	}
+
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	// A non-positive deadline does not disable the forceful stop, but falls
+	// back to the default, which is not reached.
+	drainer := drain.NewDrainer(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		drain.WithInterval(5*time.Millisecond),
+		drain.WithForceAfter(0),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := drainer.Drain(ctx, kcclient.ByUUID(uuid1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var states []instances.InstanceState
+	for _, tr := range results[0].Transitions {
+		states = append(states, tr.State)
+	}
+	if fmt.Sprint(states) != "[draining stopped]" {
+		t.Errorf("expected the stopped instance to be observed without metrics, got %v", states)
+	}
+	if results[0].Forced || results[0].Duration == 0 {
+		t.Errorf("expected the instance to stop gracefully, got %+v", results[0])
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package drain
+
+import "time"
+
+const (
+	// DefaultBatchSize is the default number of instances which are drained
+	// at the same time.
+	DefaultBatchSize = 1
+
+	// DefaultDrainTimeout is the default time the platform is given to drain
+	// an instance before stopping it.
+	DefaultDrainTimeout = time.Minute
+
+	// DefaultForceAfter is the default time after which an instance which has
+	// not stopped yet is forcefully stopped.
+	DefaultForceAfter = 90 * time.Second
+
+	// DefaultInterval is the default interval at which the state and metrics
+	// of draining instances are polled.
+	DefaultInterval = time.Second
+)
+
+// DrainerOption is an option function used during initialization of a
+// Drainer.
+type DrainerOption func(*Drainer)
+
+// WithBatchSize sets the number of instances which are drained at the same
+// time.  The next batch is only started once all instances of the previous
+// batch have stopped.
+func WithBatchSize(n int) DrainerOption {
+	return func(d *Drainer) {
+		d.batchSize = n
+	}
+}
+
+// WithDrainTimeout sets the time the platform is given to drain an instance
+// before stopping it.
+func WithDrainTimeout(timeout time.Duration) DrainerOption {
+	return func(d *Drainer) {
+		d.drainTimeout = timeout
+	}
+}
+
+// WithForceAfter sets the time after which an instance which has not stopped
+// yet is forcefully stopped.  Non-positive durations are replaced by
+// DefaultForceAfter.
+func WithForceAfter(after time.Duration) DrainerOption {
+	return func(d *Drainer) {
+		d.forceAfter = after
+	}
+}
+
+// WithInterval sets the interval at which the state and metrics of draining
+// instances are polled.  Non-positive intervals are replaced by
+// DefaultInterval.
+func WithInterval(interval time.Duration) DrainerOption {
+	return func(d *Drainer) {
+		d.interval = interval
+	}
+}
+
+// WithProgress sets a function which is called with the progress of every
+// draining instance after each poll.
+func WithProgress(fn func(Progress)) DrainerOption {
+	return func(d *Drainer) {
+		d.progress = fn
+	}
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/volumes"
+)
+
+// CreateRequest translates the configuration of an existing instance into a
+// request which creates an identical instance.  The name of the instance is
+// not carried over, since names are unique.
+//
+// Volumes are referenced by UUID.  A volume can only be attached to a single
+// instance at a time, so they must be removed from the request if the
+// original instance is kept, as Clone does.
+func (item *GetResponseItem) CreateRequest() CreateRequest {
+	req := CreateRequest{
+		Image:         ptr(item.Image),
+		Args:          slices.Clone(item.Args),
+		Env:           maps.Clone(item.Env),
+		MemoryMB:      ptr(int(item.MemoryMB)),
+		Vcpus:         ptr(item.Vcpus),
+		Autostart:     ptr(item.State != InstanceStateStopped),
+		RestartPolicy: ptr(item.RestartPolicy),
+		Features:      slices.Clone(item.Features),
+	}
+
+	if item.Vcpus == 0 {
+		req.Vcpus = nil
+	}
+	if item.RestartPolicy == "" {
+		req.RestartPolicy = nil
+	}
+
+	if item.ScaleToZero != nil {
+		stz := ScaleToZero{}
+		if item.ScaleToZero.Policy != nil {
+			stz.Policy = ptr(*item.ScaleToZero.Policy)
+		}
+		if item.ScaleToZero.Stateful != nil {
+			stz.Stateful = ptr(*item.ScaleToZero.Stateful)
+		}
+		if item.ScaleToZero.CooldownTimeMs != nil {
+			stz.CooldownTimeMs = ptr(*item.ScaleToZero.CooldownTimeMs)
+		}
+		req.ScaleToZero = &stz
+	}
+
+	if item.ServiceGroup != nil {
+		req.ServiceGroup = &CreateRequestServiceGroup{
+			UUID: ptr(item.ServiceGroup.UUID),
+		}
+	}
+
+	for _, vol := range item.Volumes {
+		req.Volumes = append(req.Volumes, CreateRequestVolume{
+			UUID:     ptr(vol.UUID),
+			At:       ptr(vol.At),
+			ReadOnly: ptr(vol.ReadOnly),
+		})
+	}
+
+	return req
+}
+
+// Clone implements InstancesService.
+func (c *client) Clone(ctx context.Context, id kcclient.Ref, mutate func(*CreateRequest)) (*kcclient.ServiceResponse[CreateResponseItem], error) {
+	item, err := c.getOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The volumes remain attached to the original instance, so the clone
+	// starts without them unless mutate attaches other volumes.
+	req := item.CreateRequest()
+	req.Volumes = nil
+	if mutate != nil {
+		mutate(&req)
+	}
+
+	return c.Create(ctx, req)
+}
+
+// Recreate implements InstancesService.
+func (c *client) Recreate(ctx context.Context, id kcclient.Ref, mutate func(*CreateRequest)) (*kcclient.ServiceResponse[CreateResponseItem], error) {
+	item, err := c.getOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	original := item.CreateRequest()
+	original.Name = ptr(item.Name)
+
+	req := item.CreateRequest()
+	req.Name = ptr(item.Name)
+	if mutate != nil {
+		mutate(&req)
+	}
+
+	// Address the instance by its UUID from here on, since the name is reused
+	// by the new instance.
+	ref := kcclient.ByUUID(item.UUID)
+
+	// Volumes can only be detached from stopped instances.
+	if item.State != InstanceStateStopped {
+		stopResp, err := c.Stop(ctx, 0, false, ref)
+		if err == nil {
+			_, err = stopResp.FirstOrErr()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("stopping instance: %w", err)
+		}
+
+		waitResp, err := c.Wait(ctx, StateStopped, DefaultWaitTimeoutMs, ref)
+		if err == nil {
+			_, err = waitResp.FirstOrErr()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("waiting for instance to stop: %w", err)
+		}
+	}
+
+	// Detach the volumes before deleting the instance, which turns volumes
+	// that were created together with the instance into persistent volumes
+	// instead of deleting them alongside it.
+	vols := volumes.NewVolumesClientFromRequest(c.request)
+	for _, vol := range item.Volumes {
+		detachResp, err := vols.Detach(ctx, kcclient.ByUUID(vol.UUID), ref)
+		if err == nil {
+			_, err = detachResp.FirstOrErr()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("detaching volume '%s': %w", vol.UUID, err)
+		}
+	}
+
+	delResp, err := c.Delete(ctx, ref)
+	if err == nil {
+		_, err = delResp.FirstOrErr()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("deleting instance: %w", err)
+	}
+
+	resp, err := c.Create(ctx, req)
+	if err == nil {
+		_, err = resp.FirstOrErr()
+	}
+	if err != nil {
+		// Attempt to restore the original instance so that a rejected change
+		// does not leave the caller without an instance.
+		restoreResp, rerr := c.Create(ctx, original)
+		if rerr == nil {
+			_, rerr = restoreResp.FirstOrErr()
+		}
+		if rerr != nil {
+			return nil, errors.Join(
+				fmt.Errorf("creating instance: %w", err),
+				fmt.Errorf("restoring original instance: %w", rerr),
+			)
+		}
+
+		return nil, fmt.Errorf("creating instance (original restored): %w", err)
+	}
+
+	return resp, nil
+}
+
+// getOne returns the state of a single instance.
+func (c *client) getOne(ctx context.Context, id kcclient.Ref) (*GetResponseItem, error) {
+	resp, err := c.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting instance: %w", err)
+	}
+
+	item, err := resp.FirstOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("getting instance: %w", err)
+	}
+
+	return item, nil
+}
+
+// ptr returns a pointer to a copy of v.
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+// fakeRecreate serves a running instance with a volume and records the
+// requests which clone or recreate it.  Instances with a rejected image or all
+// instances, if set, fail to be created.
+type fakeRecreate struct {
+	calls    []string
+	created  []instances.CreateRequest
+	rejected string
+	broken   bool
+}
+
+// serve registers the endpoints used by Clone and Recreate with the fake API.
+func (f *fakeRecreate) serve(api *fakeapi.Server) {
+	const instance = `{"status":"success","uuid":"` + uuid1 + `","name":"app","state":"stopped"}`
+
+	record := func(method, path, key string, entry string) {
+		api.Handle(method, path, key, func(r *fakeapi.Request) []string {
+			f.calls = append(f.calls, method+" "+path)
+			return []string{entry}
+		})
+	}
+
+	api.Handle(http.MethodGet, "/instances", "instances", func(r *fakeapi.Request) []string {
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app","state":"running","image":"app:v1",`+
+			`"memory_mb":256,"env":{"A":"1"},"features":["delete-on-stop"],"volumes":[{"uuid":"v1","at":"/data"}]}`, uuid1)}
+	})
+	record(http.MethodPut, "/instances/stop", "instances", instance)
+	record(http.MethodGet, "/instances/wait", "instances", instance)
+	record(http.MethodPut, "/volumes/detach", "volumes", `{"status":"success","uuid":"v1"}`)
+	record(http.MethodDelete, "/instances", "instances", instance)
+
+	api.Handle(http.MethodPost, "/instances", "instances", func(r *fakeapi.Request) []string {
+		f.calls = append(f.calls, "POST /instances")
+
+		var req instances.CreateRequest
+		_ = json.Unmarshal(r.Body, &req)
+		f.created = append(f.created, req)
+
+		if f.broken || *req.Image == f.rejected {
+			return []string{`{"status":"error","message":"image not found","error":8}`}
+		}
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q}`, uuid2, *req.Name)}
+	})
+}
+
+func TestClone(t *testing.T) {
+	fake := &fakeRecreate{}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	client := kraftcloud.NewInstancesClient().WithMetro(api.URL)
+
+	resp, err := client.Clone(context.Background(), kcclient.ByUUID(uuid1), func(req *instances.CreateRequest) {
+		req.Name = ptr("app-clone")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item, err := resp.FirstOrErr(); err != nil || item.UUID != uuid2 {
+		t.Fatalf("expected the new instance, got %+v, %v", item, err)
+	}
+
+	// The original instance is left untouched and keeps its volume.
+	if want := []string{"POST /instances"}; !slices.Equal(fake.calls, want) {
+		t.Errorf("expected %v, got %v", want, fake.calls)
+	}
+
+	req := fake.created[0]
+	if *req.Name != "app-clone" || *req.Image != "app:v1" || req.Env["A"] != "1" || len(req.Volumes) != 0 {
+		t.Errorf("expected the configuration without volumes to be carried over, got %+v", req)
+	}
+}
+
+func TestRecreate(t *testing.T) {
+	fake := &fakeRecreate{}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	client := kraftcloud.NewInstancesClient().WithMetro(api.URL)
+
+	resp, err := client.Recreate(context.Background(), kcclient.ByUUID(uuid1), func(req *instances.CreateRequest) {
+		req.Image = ptr("app:v2")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item, err := resp.FirstOrErr(); err != nil || item.UUID != uuid2 {
+		t.Fatalf("expected the new instance, got %+v, %v", item, err)
+	}
+
+	want := []string{"PUT /instances/stop", "GET /instances/wait", "PUT /volumes/detach", "DELETE /instances", "POST /instances"}
+	if !slices.Equal(fake.calls, want) {
+		t.Errorf("expected %v, got %v", want, fake.calls)
+	}
+
+	req := fake.created[0]
+	if *req.Name != "app" || *req.Image != "app:v2" || req.Env["A"] != "1" || *req.Volumes[0].UUID != "v1" ||
+		!slices.Equal(req.Features, []instances.Feature{instances.FeatureDeleteOnStop}) {
+		t.Errorf("expected the configuration to be carried over, got %+v", req)
+	}
+}
+
+func TestRecreateRestore(t *testing.T) {
+	fake := &fakeRecreate{rejected: "app:broken"}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	client := kraftcloud.NewInstancesClient().WithMetro(api.URL)
+
+	mutate := func(req *instances.CreateRequest) {
+		req.Image = ptr("app:broken")
+	}
+
+	_, err := client.Recreate(context.Background(), kcclient.ByUUID(uuid1), mutate)
+	if err == nil || !strings.Contains(err.Error(), "original restored") {
+		t.Fatalf("expected the original instance to be restored, got %v", err)
+	}
+	if len(fake.created) != 2 || *fake.created[1].Image != "app:v1" {
+		t.Errorf("expected the original configuration to be recreated, got %+v", fake.created)
+	}
+
+	api.Do(func() { fake.broken = true })
+
+	_, err = client.Recreate(context.Background(), kcclient.ByUUID(uuid1), mutate)
+	if err == nil || !strings.Contains(err.Error(), "restoring original instance") {
+		t.Errorf("expected the failed restore to be reported, got %v", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+// StopReport bundles the stop diagnosis of an instance with the last lines of
+// its console output.
+type StopReport struct {
+	// Instance is the state of the instance at the time of the report.
+	Instance GetResponseItem `json:"instance"`
+
+	// Diagnosis is the decoded stop of the instance.
+	Diagnosis StopDiagnosis `json:"diagnosis"`
+
+	// Console are the last lines of the console output of the instance.
+	Console []string `json:"console"`
+}
+
+// DiagnoseStop implements InstancesService.
+func (c *client) DiagnoseStop(ctx context.Context, id kcclient.Ref, lines int) (*StopReport, error) {
+	resp, err := c.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting instance: %w", err)
+	}
+
+	instance, err := resp.FirstOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("getting instance: %w", err)
+	}
+
+	report := &StopReport{
+		Instance:  *instance,
+		Diagnosis: instance.Diagnose(),
+	}
+
+	if lines > 0 {
+		// Address the instance by its UUID from here on, since the name may be
+		// reused if the instance is deleted in the meantime.
+		if report.Console, err = c.lastLogLines(ctx, kcclient.ByUUID(instance.UUID), lines); err != nil {
+			return nil, fmt.Errorf("getting console output: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// lastLogLines returns at most n of the last lines of the console output of the
+// instance.
+func (c *client) lastLogLines(ctx context.Context, id kcclient.Ref, n int) ([]string, error) {
+	var (
+		output []byte
+		offset int
+	)
+
+	// Page backwards through the console output until enough lines have been
+	// read or the start of the available output is reached.
+	for {
+		offset -= LogMaxPageSize
+
+		resp, err := c.Log(ctx, id, offset, LogMaxPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := resp.FirstOrErr()
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := base64.StdEncoding.DecodeString(item.Output)
+		if err != nil {
+			return nil, fmt.Errorf("decoding console output: %w", err)
+		}
+
+		output = append(page, output...)
+
+		if len(page) < LogMaxPageSize || strings.Count(string(output), "\n") > n {
+			break
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return []string{}, nil
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return lines, nil
+}
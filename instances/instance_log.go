@@ -16,21 +16,16 @@ import (
 	"time"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // Log implements InstancesService.
-func (c *client) Log(ctx context.Context, id string, offset int, limit int) (*kcclient.ServiceResponse[LogResponseItem], error) {
-	if len(id) == 0 {
+func (c *client) Log(ctx context.Context, id kcclient.Ref, offset int, limit int) (*kcclient.ServiceResponse[LogResponseItem], error) {
+	if id.IsZero() {
 		return nil, fmt.Errorf("identifier cannot be empty")
 	}
 
 	reqItem := make(map[string]any, 3)
-	if uuid.IsValid(id) {
-		reqItem["uuid"] = id
-	} else {
-		reqItem["name"] = id
-	}
+	reqItem[id.Attr()] = id.Value()
 	reqItem["offset"] = offset
 	reqItem["limit"] = limit
 
@@ -48,7 +43,7 @@ func (c *client) Log(ctx context.Context, id string, offset int, limit int) (*kc
 }
 
 // TailLogs implements InstancesService.
-func (c *client) TailLogs(ctx context.Context, id string, follow bool, tail int, delay time.Duration) (chan string, chan error, error) {
+func (c *client) TailLogs(ctx context.Context, id kcclient.Ref, follow bool, tail int, delay time.Duration) (chan string, chan error, error) {
 	var (
 		logChan = make(chan string)
 		errChan = make(chan error)
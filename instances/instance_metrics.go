@@ -14,11 +14,10 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // Metrics implements InstancesService.
-func (c *client) Metrics(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[MetricsResponseItem], error) {
+func (c *client) Metrics(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[MetricsResponseItem], error) {
 	if len(ids) == 0 {
 		return nil, errors.New("requires at least one identifier")
 	}
@@ -26,11 +25,7 @@ func (c *client) Metrics(ctx context.Context, ids ...string) (*kcclient.ServiceR
 	reqItems := make([]map[string]any, 0, len(ids))
 	for _, id := range ids {
 		reqItem := make(map[string]any, 3)
-		if uuid.IsValid(id) {
-			reqItem["uuid"] = id
-		} else {
-			reqItem["name"] = id
-		}
+		reqItem[id.Attr()] = id.Value()
 		reqItems = append(reqItems, reqItem)
 	}
 
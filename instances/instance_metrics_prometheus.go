@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+// MetricsContentTypePrometheus is the content type requested from the metrics
+// endpoint in order to receive the Prometheus text exposition format.
+const MetricsContentTypePrometheus = "text/plain; version=0.0.4"
+
+// PrometheusMetrics implements InstancesService.
+func (c *client) PrometheusMetrics(ctx context.Context, ids ...kcclient.Ref) ([]byte, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("requires at least one identifier")
+	}
+
+	reqItems := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		reqItem := make(map[string]any, 1)
+		reqItem[id.Attr()] = id.Value()
+		reqItems = append(reqItems, reqItem)
+	}
+
+	body, err := json.Marshal(reqItems)
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON object: %w", err)
+	}
+
+	resp, err := c.request.DoRawRequest(ctx, http.MethodGet, Endpoint+"/metrics", bytes.NewReader(body), MetricsContentTypePrometheus)
+	if err != nil {
+		return nil, fmt.Errorf("performing the request: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+const prometheusMetrics = `# HELP instance_rss_bytes Resident set size of the instance.
+# TYPE instance_rss_bytes gauge
+instance_rss_bytes{instance_uuid="` + uuid1 + `"} 1.048576e+06
+# HELP instance_requests_total Total number of requests to the instance.
+# TYPE instance_requests_total counter
+instance_requests_total{instance_uuid="` + uuid1 + `"} 42
+`
+
+func TestPrometheusMetrics(t *testing.T) {
+	var accept string
+	var items []map[string]string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/instances/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+
+		accept = r.Header.Get("Accept")
+		_ = json.NewDecoder(r.Body).Decode(&items)
+
+		w.Header().Set("Content-Type", instances.MetricsContentTypePrometheus)
+		_, _ = w.Write([]byte(prometheusMetrics))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := kraftcloud.NewInstancesClient().WithMetro(srv.URL)
+
+	metrics, err := cli.PrometheusMetrics(context.Background(), kcclient.ByUUID(uuid1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if accept != instances.MetricsContentTypePrometheus {
+		t.Errorf("expected the Accept header %q, got %q", instances.MetricsContentTypePrometheus, accept)
+	}
+	if len(items) != 1 || items[0]["uuid"] != uuid1 {
+		t.Errorf("expected the instance to be identified by its UUID, got %v", items)
+	}
+
+	if string(metrics) != prometheusMetrics {
+		t.Errorf("expected the raw exposition, got %q", metrics)
+	}
+}
+
+func TestPrometheusMetricsDefaultAccept(t *testing.T) {
+	var accept string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"instances":[]}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := kraftcloud.NewInstancesClient().WithMetro(srv.URL)
+
+	// Requests which do not override the Accept header still ask for JSON.
+	if _, err := cli.Metrics(context.Background(), kcclient.ByUUID(uuid1)); err != nil {
+		t.Fatal(err)
+	}
+	if accept != "application/json" {
+		t.Errorf("expected the default Accept header, got %q", accept)
+	}
+}
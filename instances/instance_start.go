@@ -14,11 +14,10 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // Start implements InstancesService.
-func (c *client) Start(ctx context.Context, waitTimeoutMs int, ids ...string) (*kcclient.ServiceResponse[StartResponseItem], error) {
+func (c *client) Start(ctx context.Context, waitTimeoutMs int, ids ...kcclient.Ref) (*kcclient.ServiceResponse[StartResponseItem], error) {
 	if len(ids) == 0 {
 		return nil, errors.New("requires at least one identifier")
 	}
@@ -26,11 +25,7 @@ func (c *client) Start(ctx context.Context, waitTimeoutMs int, ids ...string) (*
 	reqItems := make([]map[string]any, 0, len(ids))
 	for _, id := range ids {
 		reqItem := make(map[string]any, 2)
-		if uuid.IsValid(id) {
-			reqItem["uuid"] = id
-		} else {
-			reqItem["name"] = id
-		}
+		reqItem[id.Attr()] = id.Value()
 		if waitTimeoutMs > 0 {
 			reqItem["wait_timeout_ms"] = waitTimeoutMs
 		}
@@ -14,11 +14,10 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // Stop implements InstancesService.
-func (c *client) Stop(ctx context.Context, drainTimeoutMs int, force bool, ids ...string) (*kcclient.ServiceResponse[StopResponseItem], error) {
+func (c *client) Stop(ctx context.Context, drainTimeoutMs int, force bool, ids ...kcclient.Ref) (*kcclient.ServiceResponse[StopResponseItem], error) {
 	if len(ids) == 0 {
 		return nil, errors.New("requires at least one identifier")
 	}
@@ -26,11 +25,7 @@ func (c *client) Stop(ctx context.Context, drainTimeoutMs int, force bool, ids .
 	reqItems := make([]map[string]any, 0, len(ids))
 	for _, id := range ids {
 		reqItem := make(map[string]any, 3)
-		if uuid.IsValid(id) {
-			reqItem["uuid"] = id
-		} else {
-			reqItem["name"] = id
-		}
+		reqItem[id.Attr()] = id.Value()
 		reqItem["force"] = force
 		if drainTimeoutMs > 0 {
 			reqItem["drain_timeout_ms"] = drainTimeoutMs
@@ -14,11 +14,10 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // Wait implements InstancesService.
-func (c *client) Wait(ctx context.Context, state State, timeoutMs int, ids ...string) (*kcclient.ServiceResponse[WaitResponseItem], error) {
+func (c *client) Wait(ctx context.Context, state State, timeoutMs int, ids ...kcclient.Ref) (*kcclient.ServiceResponse[WaitResponseItem], error) {
 	if len(ids) == 0 {
 		return nil, errors.New("requires at least one identifier")
 	}
@@ -26,11 +25,7 @@ func (c *client) Wait(ctx context.Context, state State, timeoutMs int, ids ...st
 	reqItems := make([]map[string]any, 0, len(ids))
 	for _, id := range ids {
 		reqItem := make(map[string]any, 3)
-		if uuid.IsValid(id) {
-			reqItem["uuid"] = id
-		} else {
-			reqItem["name"] = id
-		}
+		reqItem[id.Attr()] = id.Value()
 		reqItem["state"] = state
 		reqItem["timeout_ms"] = timeoutMs
 		reqItems = append(reqItems, reqItem)
@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+const (
+	// DefaultWaitPollInterval is the minimum interval between two rounds of
+	// checks performed by WaitFor.
+	DefaultWaitPollInterval = time.Second
+
+	// DefaultWaitConcurrency is the maximum number of server-side waits
+	// performed concurrently by WaitFor.
+	DefaultWaitConcurrency = 8
+
+	// DefaultWaitRefreshAttempts is the number of consecutive rounds in which
+	// WaitFor tolerates failures to retrieve the instances.
+	DefaultWaitRefreshAttempts = 3
+
+	// overflowWaitTimeoutMs bounds the server-side waits of a round in which
+	// not all instances and states can be awaited at the same time, so that
+	// the others are still refreshed regularly.
+	overflowWaitTimeoutMs = 5000
+)
+
+// Predicate is a condition on the status of an instance which is awaited by
+// WaitFor.
+type Predicate struct {
+	// States lists the states in which the predicate can hold.  They are used
+	// for server-side long-polls.  If empty, WaitFor falls back to polling.
+	States []State
+
+	// Match reports whether the instance satisfies the predicate.
+	Match func(item *GetResponseItem) bool
+}
+
+// InStates returns a Predicate which holds if the instance is in any of the
+// given states.
+func InStates(states ...State) Predicate {
+	return Predicate{
+		States: states,
+		Match: func(item *GetResponseItem) bool {
+			return slices.Contains(states, State(item.State))
+		},
+	}
+}
+
+// ExitedWith returns a Predicate which holds if the instance is stopped and
+// its application exited with the given code.
+func ExitedWith(code uint) Predicate {
+	return Predicate{
+		States: []State{StateStopped},
+		Match: func(item *GetResponseItem) bool {
+			return State(item.State) == StateStopped &&
+				item.ExitCode != nil &&
+				*item.ExitCode == code
+		},
+	}
+}
+
+// RestartCountAbove returns a Predicate which holds once the instance has
+// been restarted more than n times.
+func RestartCountAbove(n uint) Predicate {
+	return Predicate{
+		Match: func(item *GetResponseItem) bool {
+			return item.RestartCount > n
+		},
+	}
+}
+
+// BootTimeAvailable returns a Predicate which holds once the boot time of the
+// instance has been measured.
+func BootTimeAvailable() Predicate {
+	return Predicate{
+		Match: func(item *GetResponseItem) bool {
+			return item.BootTimeUs > 0
+		},
+	}
+}
+
+// AllOf returns a Predicate which holds if all of the given predicates hold.
+func AllOf(preds ...Predicate) Predicate {
+	var states []State
+	for _, pred := range preds {
+		if len(pred.States) == 0 {
+			continue
+		}
+		if states == nil {
+			states = slices.Clone(pred.States)
+			continue
+		}
+		states = slices.DeleteFunc(states, func(s State) bool {
+			return !slices.Contains(pred.States, s)
+		})
+	}
+
+	return Predicate{
+		States: states,
+		Match: func(item *GetResponseItem) bool {
+			for _, pred := range preds {
+				if !pred.Match(item) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// AnyOf returns a Predicate which holds if any of the given predicates holds.
+func AnyOf(preds ...Predicate) Predicate {
+	var states []State
+	for _, pred := range preds {
+		// A predicate which may hold in any state rules out long-polls.
+		if len(pred.States) == 0 {
+			states = nil
+			break
+		}
+		for _, s := range pred.States {
+			if !slices.Contains(states, s) {
+				states = append(states, s)
+			}
+		}
+	}
+
+	return Predicate{
+		States: states,
+		Match: func(item *GetResponseItem) bool {
+			for _, pred := range preds {
+				if pred.Match(item) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// WaitForResult is the outcome of waiting for a single instance.
+type WaitForResult struct {
+	// ID is the reference to the instance as passed to WaitFor.
+	ID kcclient.Ref
+
+	// Instance is the last observed status of the instance.  It is nil if the
+	// instance could not be retrieved.
+	Instance *GetResponseItem
+
+	// Err is nil if the predicate holds for Instance.
+	Err error
+}
+
+// WaitFor implements InstancesService.
+func (c *client) WaitFor(ctx context.Context, ids []kcclient.Ref, predicate Predicate) ([]WaitForResult, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("requires at least one identifier")
+	}
+	if predicate.Match == nil {
+		return nil, errors.New("predicate has no match function")
+	}
+
+	results := make([]WaitForResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+	}
+
+	failures := 0
+
+	for {
+		start := time.Now()
+
+		var pending []int
+		for i, res := range results {
+			if res.Err == nil && !satisfied(predicate, res.Instance) {
+				pending = append(pending, i)
+			}
+		}
+
+		if len(pending) == 0 {
+			break
+		}
+
+		if ctx.Err() != nil {
+			for _, i := range pending {
+				results[i].Err = fmt.Errorf("waiting for instance '%s': %w", results[i].ID, ctx.Err())
+			}
+			break
+		}
+
+		// The first round only retrieves the instances, as the predicate may
+		// already hold.
+		if results[pending[0]].Instance != nil {
+			var uuids []string
+			for _, i := range pending {
+				uuids = append(uuids, results[i].Instance.UUID)
+			}
+
+			c.longPoll(ctx, predicate.States, uuids)
+
+			// Guard against server-side waits which return immediately.
+			select {
+			case <-ctx.Done():
+			case <-time.After(DefaultWaitPollInterval - time.Since(start)):
+			}
+		}
+
+		// Failures to retrieve the instances are retried in the next rounds
+		// before they fail the remaining instances.
+		if err := c.refresh(ctx, results, pending); err != nil {
+			failures++
+			if failures < DefaultWaitRefreshAttempts {
+				select {
+				case <-ctx.Done():
+				case <-time.After(DefaultWaitPollInterval - time.Since(start)):
+				}
+				continue
+			}
+
+			for _, i := range pending {
+				results[i].Err = fmt.Errorf("waiting for instance '%s': %w", results[i].ID, err)
+			}
+			break
+		}
+		failures = 0
+	}
+
+	var errs []error
+	for _, res := range results {
+		if res.Err != nil {
+			errs = append(errs, res.Err)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// satisfied returns whether the predicate holds for the instance.
+func satisfied(predicate Predicate, item *GetResponseItem) bool {
+	return item != nil && predicate.Match(item)
+}
+
+// refresh retrieves the current status of the instances at the given indices
+// of results.  Instances which cannot be retrieved get a terminal error.
+func (c *client) refresh(ctx context.Context, results []WaitForResult, indices []int) error {
+	ids := make([]kcclient.Ref, 0, len(indices))
+	for _, i := range indices {
+		id := results[i].ID
+		if results[i].Instance != nil {
+			id = kcclient.ByUUID(results[i].Instance.UUID)
+		}
+		ids = append(ids, id)
+	}
+
+	resp, err := c.Get(ctx, ids...)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Keep the last observed status if the context expired mid-request.
+			return nil
+		}
+		return fmt.Errorf("getting instances: %w", err)
+	}
+
+	items := resp.Data.Entries
+	if len(items) != len(ids) {
+		return fmt.Errorf("expected %d instances in response, got %d", len(ids), len(items))
+	}
+
+	for n, i := range indices {
+		item := items[n]
+		if item.Error != nil {
+			results[i].Err = fmt.Errorf("getting instance '%s': %s (code=%d)", results[i].ID, item.Message, *item.Error)
+			continue
+		}
+		results[i].Instance = &item
+	}
+
+	return nil
+}
+
+// longPoll blocks until any of the instances reaches any of the given states,
+// the server-side timeout expires or the context is done.  If no states are
+// given, it returns immediately.
+func (c *client) longPoll(ctx context.Context, states []State, uuids []string) {
+	if len(states) == 0 {
+		return
+	}
+
+	timeoutMs := DefaultWaitTimeoutMs
+	if deadline, ok := ctx.Deadline(); ok {
+		timeoutMs = min(timeoutMs, int(time.Until(deadline).Milliseconds()))
+	}
+	if timeoutMs <= 0 {
+		return
+	}
+
+	type wait struct {
+		uuid  string
+		state State
+	}
+
+	// Each instance and state is awaited separately, as a wait on multiple
+	// instances only returns once all of them reached the state.
+	waits := make([]wait, 0, len(uuids)*len(states))
+	for _, uuid := range uuids {
+		for _, state := range states {
+			waits = append(waits, wait{uuid: uuid, state: state})
+		}
+	}
+
+	workers := min(len(waits), DefaultWaitConcurrency)
+	if workers < len(waits) {
+		timeoutMs = min(timeoutMs, overflowWaitTimeoutMs)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan wait)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for w := range jobs {
+				// Errors, including timeouts, are handled by the next
+				// refresh.
+				_, _ = c.Wait(ctx, w.state, timeoutMs, kcclient.ByUUID(w.uuid))
+				cancel()
+			}
+		}()
+	}
+
+	// Waits which have not started once the first one returns are skipped.
+feed:
+	for _, w := range waits {
+		select {
+		case jobs <- w:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+
+	wg.Wait()
+}
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+func TestWaitFor(t *testing.T) {
+	stopped := make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqItems []map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&reqItems); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/instances/wait":
+			select {
+			case <-stopped:
+			case <-r.Context().Done():
+				return
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"instances":[{"status":"success","uuid":%q,"state":"stopped"}]}}`, uuid1)
+
+		case "/instances":
+			entries := make([]string, 0, len(reqItems))
+			for _, item := range reqItems {
+				id, _ := item["uuid"].(string)
+				if id == "" {
+					id, _ = item["name"].(string)
+				}
+
+				switch id {
+				case uuid1, "app":
+					state, exit := "running", ""
+					select {
+					case <-stopped:
+						state, exit = "stopped", `,"exit_code":0`
+					default:
+					}
+					entries = append(entries, fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app","state":%q%s}`, uuid1, state, exit))
+				default:
+					entries = append(entries, fmt.Sprintf(`{"status":"error","name":%q,"message":"instance not found","error":8}`, id))
+				}
+			}
+			fmt.Fprintf(w, `{"status":"partial_success","data":{"instances":[%s]}}`, strings.Join(entries, ","))
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := kraftcloud.NewInstancesClient().WithMetro(srv.URL)
+
+	time.AfterFunc(50*time.Millisecond, func() { close(stopped) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results, err := cli.WaitFor(ctx, []kcclient.Ref{kcclient.ByName("app"), kcclient.ByName("missing")}, instances.ExitedWith(0))
+	if err == nil {
+		t.Fatal("expected an error for the missing instance")
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	if res := results[0]; res.Err != nil || res.Instance == nil || res.Instance.State != instances.InstanceStateStopped {
+		t.Errorf("expected instance 'app' to be stopped, got %+v", res)
+	}
+	if res := results[1]; res.Err == nil || res.Instance != nil {
+		t.Errorf("expected instance 'missing' to fail, got %+v", res)
+	}
+
+	// A predicate which never holds fails once the context is done and keeps
+	// the last observed status.
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	results, err = cli.WaitFor(ctx, []kcclient.Ref{kcclient.ByName("app")}, instances.RestartCountAbove(0))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if results[0].Instance == nil || results[0].Instance.UUID != uuid1 {
+		t.Errorf("expected last observed status, got %+v", results[0])
+	}
+}
+
+func TestWaitForConcurrency(t *testing.T) {
+	const n = 3 * instances.DefaultWaitConcurrency
+
+	var (
+		mu              sync.Mutex
+		waits, maxWaits int
+		stopped         = make(chan struct{})
+		uuids           []string
+	)
+	for i := range n {
+		uuids = append(uuids, fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1))
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqItems []map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&reqItems); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		state := "running"
+		select {
+		case <-stopped:
+			state = "stopped"
+		default:
+		}
+
+		switch r.URL.Path {
+		case "/instances/wait":
+			mu.Lock()
+			waits++
+			maxWaits = max(maxWaits, waits)
+			mu.Unlock()
+
+			defer func() {
+				mu.Lock()
+				waits--
+				mu.Unlock()
+			}()
+
+			select {
+			case <-stopped:
+			case <-r.Context().Done():
+				return
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"instances":[{"status":"success","uuid":%q,"state":"stopped"}]}}`, reqItems[0]["uuid"])
+
+		case "/instances":
+			entries := make([]string, 0, len(reqItems))
+			for _, item := range reqItems {
+				entries = append(entries, fmt.Sprintf(`{"status":"success","uuid":%q,"state":%q}`, item["uuid"], state))
+			}
+			fmt.Fprintf(w, `{"status":"success","data":{"instances":[%s]}}`, strings.Join(entries, ","))
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := kraftcloud.NewInstancesClient().WithMetro(srv.URL)
+
+	time.AfterFunc(100*time.Millisecond, func() { close(stopped) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := cli.WaitFor(ctx, kcclient.UUIDs(uuids...), instances.InStates(instances.StateStopped)); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if maxWaits == 0 || maxWaits > instances.DefaultWaitConcurrency {
+		t.Errorf("expected at most %d concurrent waits, got %d", instances.DefaultWaitConcurrency, maxWaits)
+	}
+}
+
+func TestWaitForRetries(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		failing = 1
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/instances" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failing > 0 {
+			failing--
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintf(w, `{"status":"success","data":{"instances":[{"status":"success","uuid":%q,"state":"running"}]}}`, uuid1)
+	}))
+	t.Cleanup(srv.Close)
+
+	cli := kraftcloud.NewInstancesClient().WithMetro(srv.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A transient failure is retried.
+	results, err := cli.WaitFor(ctx, kcclient.UUIDs(uuid1), instances.InStates(instances.StateRunning))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Instance == nil || results[0].Instance.UUID != uuid1 {
+		t.Errorf("expected the instance to be retrieved, got %+v", results[0])
+	}
+
+	// Persistent failures fail the instance, but the results are returned.
+	mu.Lock()
+	failing = instances.DefaultWaitRefreshAttempts
+	mu.Unlock()
+
+	results, err = cli.WaitFor(ctx, kcclient.UUIDs(uuid1), instances.InStates(instances.StateRunning))
+	if err == nil || len(results) != 1 || results[0].Err == nil {
+		t.Errorf("expected the instance to fail after %d attempts, got %+v", instances.DefaultWaitRefreshAttempts, results)
+	}
+}
+
+func TestPredicateCombinators(t *testing.T) {
+	running := instances.InStates(instances.StateRunning)
+	stopped := instances.InStates(instances.StateStopped)
+
+	either := instances.AnyOf(running, stopped)
+	if len(either.States) != 2 {
+		t.Errorf("expected AnyOf to long-poll on 2 states, got %v", either.States)
+	}
+	if len(instances.AnyOf(running, instances.BootTimeAvailable()).States) != 0 {
+		t.Error("expected AnyOf with a stateless predicate to poll")
+	}
+
+	all := instances.AllOf(instances.AnyOf(running, stopped), instances.ExitedWith(0))
+	if len(all.States) != 1 || all.States[0] != instances.StateStopped {
+		t.Errorf("expected AllOf to long-poll on the stopped state, got %v", all.States)
+	}
+
+	exit := uint(0)
+	if !all.Match(&instances.GetResponseItem{State: instances.InstanceStateStopped, ExitCode: &exit}) {
+		t.Error("expected stopped instance with exit code 0 to match")
+	}
+	if all.Match(&instances.GetResponseItem{State: instances.InstanceStateRunning}) {
+		t.Error("expected running instance not to match")
+	}
+}
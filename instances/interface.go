@@ -26,14 +26,14 @@ type InstancesService interface {
 	// Get returns the current state and the configuration of one or more instance(s).
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/#getting-the-status-of-an-instance
-	Get(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[GetResponseItem], error)
+	Get(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[GetResponseItem], error)
 
 	// Delete deletes the specified instance(s).
 	// After this call the UUIDs of the instances are no longer valid. If the
 	// instances are currently running, they are force stopped.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/#deleting-an-instance
-	Delete(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[DeleteResponseItem], error)
+	Delete(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[DeleteResponseItem], error)
 
 	// Lists all existing instances.
 	//
@@ -44,7 +44,7 @@ type InstancesService interface {
 	// Does nothing for instances that are already running.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/#starting-an-instance
-	Start(ctx context.Context, waitTimeoutMs int, ids ...string) (*kcclient.ServiceResponse[StartResponseItem], error)
+	Start(ctx context.Context, waitTimeoutMs int, ids ...kcclient.Ref) (*kcclient.ServiceResponse[StartResponseItem], error)
 
 	// Stop stops the specified instance(s), but does not destroy them.
 	// All volatile state (e.g., RAM contents) is lost. Does nothing for
@@ -52,43 +52,76 @@ type InstancesService interface {
 	// the start endpoint.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/#stopping-an-instance
-	Stop(ctx context.Context, drainTimeoutMs int, force bool, ids ...string) (*kcclient.ServiceResponse[StopResponseItem], error)
+	Stop(ctx context.Context, drainTimeoutMs int, force bool, ids ...kcclient.Ref) (*kcclient.ServiceResponse[StopResponseItem], error)
 
 	// Log returns the console output of the specified instance.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/#retrieve-the-console-output
-	Log(ctx context.Context, id string, offset int, limit int) (*kcclient.ServiceResponse[LogResponseItem], error)
+	Log(ctx context.Context, id kcclient.Ref, offset int, limit int) (*kcclient.ServiceResponse[LogResponseItem], error)
 
 	// Metrics returns the metrics of the specified instance(s).
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/#metrics
-	Metrics(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[MetricsResponseItem], error)
+	Metrics(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[MetricsResponseItem], error)
+
+	// PrometheusMetrics returns the raw metrics of the specified instance(s) in
+	// the Prometheus text exposition format.  Some metrics are only exposed in
+	// this format.  They can be parsed with the promexport package.
+	//
+	// See: https://docs.kraft.cloud/api/v1/instances/#metrics
+	PrometheusMetrics(ctx context.Context, ids ...kcclient.Ref) ([]byte, error)
 
 	// TailLogs is a utility method which returns a channel that streams the
 	// console output of the specified instance.
-	TailLogs(ctx context.Context, id string, follow bool, tail int, delay time.Duration) (chan string, chan error, error)
+	TailLogs(ctx context.Context, id kcclient.Ref, follow bool, tail int, delay time.Duration) (chan string, chan error, error)
+
+	// DiagnoseStop is a utility method which returns the decoded stop details of
+	// the specified instance together with the last lines of its console
+	// output.
+	DiagnoseStop(ctx context.Context, id kcclient.Ref, lines int) (*StopReport, error)
 
 	// Wait waits for the specified instance(s) to reach the desired state.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/#waiting-for-an-instance-to-reach-a-desired-state
-	Wait(ctx context.Context, state State, timeoutMs int, ids ...string) (*kcclient.ServiceResponse[WaitResponseItem], error)
+	Wait(ctx context.Context, state State, timeoutMs int, ids ...kcclient.Ref) (*kcclient.ServiceResponse[WaitResponseItem], error)
+
+	// WaitFor is a utility method which waits until the predicate holds for
+	// each of the specified instances, or the context is done.  Server-side
+	// waits are repeated transparently and failures to retrieve the
+	// instances are retried.  The returned error combines the errors of all
+	// instances for which the predicate does not hold.
+	WaitFor(ctx context.Context, ids []kcclient.Ref, predicate Predicate) ([]WaitForResult, error)
+
+	// Clone is a utility method which creates a new instance with the same
+	// configuration as the specified instance, except for its volumes, which
+	// can only be attached to one instance at a time.  The optional mutate
+	// function can alter the request before the instance is created.
+	Clone(ctx context.Context, id kcclient.Ref, mutate func(*CreateRequest)) (*kcclient.ServiceResponse[CreateResponseItem], error)
+
+	// Recreate is a utility method which replaces the specified instance with a
+	// new instance under the same name, since instance properties cannot be
+	// changed after creation.  The old instance is stopped, its volumes are
+	// detached and it is deleted before the new instance is created with the
+	// same volumes.  If the new instance cannot be created, the original
+	// configuration is restored.
+	Recreate(ctx context.Context, id kcclient.Ref, mutate func(*CreateRequest)) (*kcclient.ServiceResponse[CreateResponseItem], error)
 
 	// CreateTemplate creates a new instance template with the given configuration.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/templates#creating-a-template
-	CreateTemplate(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[TemplateCreateResponseItem], error)
+	CreateTemplate(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[TemplateCreateResponseItem], error)
 
 	// GetTemplate returns the current state and the configuration of volume
 	// templates.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/templates#getting-the-status-of-a-template
-	GetTemplate(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[TemplateGetResponseItem], error)
+	GetTemplate(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[TemplateGetResponseItem], error)
 
 	// Delete deletes the specified template(s).
 	// After this call the UUID of the templates is no longer valid.
 	//
 	// See: https://docs.kraft.cloud/api/v1/instances/templates#deleting-a-template
-	DeleteTemplate(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[TemplateDeleteResponseItem], error)
+	DeleteTemplate(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[TemplateDeleteResponseItem], error)
 
 	// Lists all existing templates.
 	//
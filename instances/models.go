@@ -71,6 +71,11 @@ type CreateResponseItem struct {
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i CreateResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
 type InstanceState string
 
 const (
@@ -107,9 +112,9 @@ type GetResponseItem struct {
 	Status            string                         `json:"status"`
 	UUID              string                         `json:"uuid"`
 	Name              string                         `json:"name"`
-	CreatedAt         string                         `json:"created_at"`
-	StartedAt         string                         `json:"started_at"`
-	StoppedAt         string                         `json:"stopped_at"`
+	CreatedAt         kcclient.Timestamp             `json:"created_at"`
+	StartedAt         kcclient.Timestamp             `json:"started_at"`
+	StoppedAt         kcclient.Timestamp             `json:"stopped_at"`
 	UptimeMs          int                            `json:"uptime_ms"`
 	Restart           *GetResponseRestart            `json:"restart,omitempty"`
 	RestartPolicy     RestartPolicy                  `json:"restart_policy"`
@@ -132,10 +137,16 @@ type GetResponseItem struct {
 	NetworkInterfaces []GetResponseNetworkInterface  `json:"network_interfaces"`
 	BootTimeUs        int                            `json:"boot_time_us"` // always returned, even if never started
 	Snapshot          InstanceSnapshot               `json:"snapshot"`
+	Features          []Feature                      `json:"features,omitempty"`
 
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i GetResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
 // Stop code of the kernel.  This value encodes multiple details about the stop
 // irrespective of the application.
 //
@@ -393,11 +404,10 @@ func (item *GetResponseItem) StopReasonCode() string {
 func (item *GetResponseItem) DescribeStatus() string {
 	switch item.State {
 	case InstanceStateRunning:
-		started, err := time.Parse(time.RFC3339, item.StartedAt)
-		if err != nil {
-			return err.Error()
+		if item.StartedAt.IsZero() {
+			return string(item.State)
 		}
-		dur := time.Since(started)
+		dur := time.Since(item.StartedAt.Time)
 
 		days := int64(dur.Hours() / 24)
 		hours := int64(math.Mod(dur.Hours(), 24))
@@ -444,8 +454,8 @@ func (item *GetResponseItem) DescribeStatus() string {
 }
 
 type GetResponseRestart struct {
-	Attempt int    `json:"attempt"`
-	NextAt  string `json:"next_at"`
+	Attempt int                `json:"attempt"`
+	NextAt  kcclient.Timestamp `json:"next_at"`
 }
 
 type GetCreateResponseServiceGroup struct {
@@ -543,10 +553,10 @@ type MetricsResponseItem struct {
 	RestartCount uint `json:"restart_count"`
 
 	// Date and time of last start in ISO8601
-	StartedAt string `json:"started_at"`
+	StartedAt kcclient.Timestamp `json:"started_at"`
 
 	// Date and time of last stop in ISO8601
-	StoppedAt string `json:"stopped_at"`
+	StoppedAt kcclient.Timestamp `json:"stopped_at"`
 
 	// Uptime of instance in milliseconds
 	UptimeMs uint `json:"uptime_ms"`
@@ -644,7 +654,7 @@ type TemplateGetResponseItem struct {
 	Status        string              `json:"status"`
 	UUID          string              `json:"uuid"`
 	Name          string              `json:"name"`
-	CreatedAt     string              `json:"created_at"`
+	CreatedAt     kcclient.Timestamp  `json:"created_at"`
 	State         InstanceState       `json:"state"`
 	Image         string              `json:"image"`
 	MemoryMB      uint                `json:"memory_mb"`
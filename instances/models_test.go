@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+func TestIdentity(t *testing.T) {
+	var gets int
+
+	api := fakeapi.New(t)
+	api.Handle(http.MethodPost, "/instances", "instances", func(r *fakeapi.Request) []string {
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app","state":"starting"}`, uuid1)}
+	})
+	api.Handle(http.MethodGet, "/instances", "instances", func(r *fakeapi.Request) []string {
+		gets++
+		return []string{`{"status":"error","message":"instance not found","error":8}`}
+	})
+	api.Handle(http.MethodDelete, "/instances", "instances", func(r *fakeapi.Request) []string {
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app"}`, uuid1)}
+	})
+
+	// The response entries identify the instances, so that the client plugs
+	// into the generic resolver.
+	client := kraftcloud.NewInstancesClient().WithMetro(api.URL)
+	resolver := kcclient.NewServiceResolver(client.Get, client.List)
+	svc := kcclient.NewResolvingService(resolver, client.Create, client.Delete)
+
+	ctx := context.Background()
+
+	if _, err := svc.Create(ctx, instances.CreateRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if uuid, err := resolver.Resolve(ctx, kcclient.ByName("app")); err != nil || uuid != uuid1 {
+		t.Fatalf("expected the created instance to resolve to %s, got %s, %v", uuid1, uuid, err)
+	}
+
+	if _, err := svc.Delete(ctx, kcclient.ByName("app")); err != nil {
+		t.Fatal(err)
+	}
+
+	api.Do(func() {
+		if gets != 0 {
+			t.Errorf("expected no lookups before the deletion, got %d", gets)
+		}
+	})
+
+	if _, err := resolver.Resolve(ctx, kcclient.ByName("app")); err == nil {
+		t.Error("expected the deleted instance not to resolve")
+	}
+}
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package probe checks whether applications running in instances on
+// KraftCloud are serving, beyond the instance merely being in the running
+// state.
+package probe
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"sdk.kraft.cloud/instances"
+)
+
+// Kind is the kind of check performed by a probe.
+type Kind string
+
+const (
+	// KindHTTP performs an HTTP request and checks the response status.
+	KindHTTP Kind = "http"
+
+	// KindTCP only checks that a TCP connection can be established.
+	KindTCP Kind = "tcp"
+)
+
+// Probe defines how and how often an instance is checked.
+type Probe struct {
+	// Kind of the check.  Defaults to KindHTTP.
+	Kind Kind
+
+	// Scheme used for HTTP checks, either "http" or "https".  Defaults to
+	// "https".
+	Scheme string
+
+	// Path requested by HTTP checks.  Defaults to "/".
+	Path string
+
+	// ExpectedStatus is the status code expected from HTTP checks.  If unset,
+	// any status code between 200 and 399 is accepted.
+	ExpectedStatus int
+
+	// Interval between two checks.  Defaults to 5s.
+	Interval time.Duration
+
+	// Timeout of a single check.  Defaults to 1s.
+	Timeout time.Duration
+
+	// SuccessThreshold is the number of consecutive successful checks after
+	// which a target is considered ready.  Defaults to 1.
+	SuccessThreshold int
+
+	// FailureThreshold is the number of consecutive failed checks after which
+	// a target is considered not ready.  Defaults to 3.
+	FailureThreshold int
+}
+
+// withDefaults returns a copy of p with all unset fields set to their default.
+func (p Probe) withDefaults() Probe {
+	if p.Kind == "" {
+		p.Kind = KindHTTP
+	}
+	if p.Scheme == "" {
+		p.Scheme = "https"
+	}
+	if p.Path == "" {
+		p.Path = "/"
+	}
+	if p.Interval <= 0 {
+		p.Interval = 5 * time.Second
+	}
+	if p.Timeout <= 0 {
+		p.Timeout = time.Second
+	}
+	if p.SuccessThreshold <= 0 {
+		p.SuccessThreshold = 1
+	}
+	if p.FailureThreshold <= 0 {
+		p.FailureThreshold = 3
+	}
+
+	return p
+}
+
+// Target is the endpoint at which an instance is probed.
+type Target struct {
+	// UUID of the probed instance.
+	UUID string
+
+	// Name of the probed instance.
+	Name string
+
+	// Address is the host:port which is dialed.
+	Address string
+
+	// Host is sent as the HTTP Host header and used as the TLS server name.
+	// Defaults to the host part of Address.
+	Host string
+}
+
+// key returns the key under which the status of the target is recorded.
+func (t Target) key() string {
+	if t.UUID != "" {
+		return t.UUID
+	}
+	return t.Address
+}
+
+// Resolver determines the Target of an instance.
+type Resolver func(item *instances.GetResponseItem) (Target, error)
+
+// ServiceGroupResolver returns a Resolver which reaches instances through the
+// first domain of their service group on the given port.  Since the domain is
+// served by all instances of the group, a check succeeds if any instance of
+// the group serves it.
+func ServiceGroupResolver(port int) Resolver {
+	return func(item *instances.GetResponseItem) (Target, error) {
+		if item.ServiceGroup == nil || len(item.ServiceGroup.Domains) == 0 {
+			return Target{}, fmt.Errorf("instance '%s' has no service group domain", item.Name)
+		}
+
+		fqdn := item.ServiceGroup.Domains[0].FQDN
+
+		return Target{
+			UUID:    item.UUID,
+			Name:    item.Name,
+			Address: net.JoinHostPort(fqdn, strconv.Itoa(port)),
+			Host:    fqdn,
+		}, nil
+	}
+}
+
+// PrivateServiceGroupResolver returns a Resolver which reaches each instance
+// of a service group individually through its private FQDN on the given port,
+// presenting the first domain of the service group as host.  The prober must
+// be given a dialer which can reach the private network of the instances, and
+// probes typically use the "http" scheme, since TLS is terminated at the
+// service group.
+func PrivateServiceGroupResolver(port int) Resolver {
+	return func(item *instances.GetResponseItem) (Target, error) {
+		if item.ServiceGroup == nil || len(item.ServiceGroup.Domains) == 0 {
+			return Target{}, fmt.Errorf("instance '%s' has no service group domain", item.Name)
+		}
+		if item.PrivateFQDN == "" {
+			return Target{}, fmt.Errorf("instance '%s' has no private FQDN", item.Name)
+		}
+
+		return Target{
+			UUID:    item.UUID,
+			Name:    item.Name,
+			Address: net.JoinHostPort(item.PrivateFQDN, strconv.Itoa(port)),
+			Host:    item.ServiceGroup.Domains[0].FQDN,
+		}, nil
+	}
+}
+
+// PrivateResolver returns a Resolver which reaches instances through their
+// private IP address on the given port.  The prober must be given a dialer
+// which can reach the private network of the instances.
+func PrivateResolver(port int) Resolver {
+	return func(item *instances.GetResponseItem) (Target, error) {
+		if item.PrivateIP == "" {
+			return Target{}, errors.New("instance has no private IP address")
+		}
+
+		return Target{
+			UUID:    item.UUID,
+			Name:    item.Name,
+			Address: net.JoinHostPort(item.PrivateIP, strconv.Itoa(port)),
+			Host:    item.PrivateFQDN,
+		}, nil
+	}
+}
+
+// State is the readiness state of a target.
+type State string
+
+const (
+	// StateUnknown indicates that not enough checks have been performed.
+	StateUnknown State = "unknown"
+
+	// StateReady indicates that the target passed its success threshold.
+	StateReady State = "ready"
+
+	// StateNotReady indicates that the target passed its failure threshold.
+	StateNotReady State = "not_ready"
+)
+
+// Result is the outcome of a single check.
+type Result struct {
+	// Time at which the check was started.
+	Time time.Time
+
+	// Latency of the check.
+	Latency time.Duration
+
+	// StatusCode of the response to an HTTP check.
+	StatusCode int
+
+	// Err is set if the check failed.
+	Err error
+}
+
+// Success returns whether the check succeeded.
+func (r Result) Success() bool {
+	return r.Err == nil
+}
+
+// Status is the readiness of a target, derived from its recent checks.
+type Status struct {
+	// State is the readiness state of the target.
+	State State
+
+	// Successes is the number of consecutive successful checks.
+	Successes int
+
+	// Failures is the number of consecutive failed checks.
+	Failures int
+
+	// Last is the result of the most recent check.
+	Last Result
+}
+
+// record updates the status with the result of a check.
+func (s *Status) record(p Probe, r Result) {
+	s.Last = r
+
+	if r.Success() {
+		s.Successes++
+		s.Failures = 0
+		if s.Successes >= p.SuccessThreshold {
+			s.State = StateReady
+		}
+	} else {
+		s.Failures++
+		s.Successes = 0
+		if s.Failures >= p.FailureThreshold {
+			s.State = StateNotReady
+		}
+	}
+}
@@ -0,0 +1,364 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+// Dialer establishes the connections over which targets are probed, e.g. a
+// *net.Dialer or a dialer tunnelling into the private network of instances.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// Prober periodically checks targets and keeps track of their readiness.
+type Prober struct {
+	probe     Probe
+	dialer    Dialer
+	tlsConfig *tls.Config
+	resolver  Resolver
+	now       func() time.Time
+
+	mu       sync.Mutex
+	statuses map[string]*Status
+	notify   chan struct{}
+}
+
+// ProberOption is an option function used during initialization of a Prober.
+type ProberOption func(*Prober)
+
+// WithDialer sets the dialer used to connect to targets.
+func WithDialer(dialer Dialer) ProberOption {
+	return func(p *Prober) {
+		p.dialer = dialer
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used by HTTPS checks.  The server
+// name is always set to the host of the target.
+func WithTLSConfig(config *tls.Config) ProberOption {
+	return func(p *Prober) {
+		p.tlsConfig = config
+	}
+}
+
+// WithResolver sets the resolver which determines the target of an instance.
+// Defaults to ServiceGroupResolver on port 443.  PrivateResolver and
+// PrivateServiceGroupResolver probe instances individually, but require a
+// dialer into the private network of the instances.
+func WithResolver(resolver Resolver) ProberOption {
+	return func(p *Prober) {
+		p.resolver = resolver
+	}
+}
+
+// WithClock sets the function returning the current time.
+func WithClock(now func() time.Time) ProberOption {
+	return func(p *Prober) {
+		p.now = now
+	}
+}
+
+// NewProber instantiates a new Prober which checks targets as defined by
+// probe.
+func NewProber(probe Probe, popts ...ProberOption) *Prober {
+	p := &Prober{
+		probe:    probe.withDefaults(),
+		dialer:   &net.Dialer{},
+		resolver: ServiceGroupResolver(443),
+		now:      time.Now,
+		statuses: make(map[string]*Status),
+		notify:   make(chan struct{}),
+	}
+
+	for _, opt := range popts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Check performs a single check of the target, without recording its result.
+func (p *Prober) Check(ctx context.Context, target Target) Result {
+	ctx, cancel := context.WithTimeout(ctx, p.probe.Timeout)
+	defer cancel()
+
+	res := Result{Time: p.now()}
+	start := time.Now()
+
+	switch p.probe.Kind {
+	case KindTCP:
+		res.Err = p.checkTCP(ctx, target)
+	case KindHTTP:
+		res.StatusCode, res.Err = p.checkHTTP(ctx, target)
+	default:
+		res.Err = fmt.Errorf("unknown probe kind '%s'", p.probe.Kind)
+	}
+
+	res.Latency = time.Since(start)
+
+	return res
+}
+
+func (p *Prober) checkTCP(ctx context.Context, target Target) error {
+	conn, err := p.dialer.DialContext(ctx, "tcp", target.Address)
+	if err != nil {
+		return fmt.Errorf("dialing: %w", err)
+	}
+
+	return conn.Close()
+}
+
+func (p *Prober) checkHTTP(ctx context.Context, target Target) (int, error) {
+	host := target.Host
+	if host == "" {
+		h, _, err := net.SplitHostPort(target.Address)
+		if err != nil {
+			return 0, fmt.Errorf("parsing address: %w", err)
+		}
+		host = h
+	}
+
+	tlsConfig := &tls.Config{}
+	if p.tlsConfig != nil {
+		tlsConfig = p.tlsConfig.Clone()
+	}
+	tlsConfig.ServerName = host
+
+	// Always dial the address of the target, regardless of the host which is
+	// presented to the application.
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return p.dialer.DialContext(ctx, network, target.Address)
+		},
+		TLSClientConfig:   tlsConfig,
+		DisableKeepAlives: true,
+	}
+	defer transport.CloseIdleConnections()
+
+	url := fmt.Sprintf("%s://%s%s", p.probe.Scheme, target.Address, p.probe.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating the request: %w", err)
+	}
+	req.Host = host
+
+	resp, err := (&http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}).Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("performing the request: %w", err)
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if p.probe.ExpectedStatus != 0 {
+		if resp.StatusCode != p.probe.ExpectedStatus {
+			return resp.StatusCode, fmt.Errorf("unexpected status code %d, expected %d", resp.StatusCode, p.probe.ExpectedStatus)
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Record checks the target once and records the result in its status, which
+// is returned.  A check which is aborted because the context is done is not
+// recorded.
+func (p *Prober) Record(ctx context.Context, target Target) Status {
+	res := p.Check(ctx, target)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	status, ok := p.statuses[target.key()]
+	if !ok {
+		status = &Status{State: StateUnknown}
+		p.statuses[target.key()] = status
+	}
+
+	if ctx.Err() != nil {
+		return *status
+	}
+
+	before := status.State
+	status.record(p.probe, res)
+
+	if status.State != before {
+		close(p.notify)
+		p.notify = make(chan struct{})
+	}
+
+	return *status
+}
+
+// Run checks the targets at the probe interval until the context is done.
+func (p *Prober) Run(ctx context.Context, targets ...Target) {
+	var wg sync.WaitGroup
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target Target) {
+			defer wg.Done()
+
+			ticker := time.NewTicker(p.probe.Interval)
+			defer ticker.Stop()
+
+			for {
+				p.Record(ctx, target)
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(target)
+	}
+
+	wg.Wait()
+}
+
+// Status returns the readiness of the instance with the given UUID, or of the
+// target with the given address if it has no UUID.  The state of targets which
+// have not been checked yet is StateUnknown.
+func (p *Prober) Status(id string) Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if status, ok := p.statuses[id]; ok {
+		return *status
+	}
+
+	return Status{State: StateUnknown}
+}
+
+// Ready returns whether the instance with the given UUID, or the target with
+// the given address, is ready.
+func (p *Prober) Ready(id string) bool {
+	return p.Status(id).State == StateReady
+}
+
+// Forget removes the recorded status of the instances with the given UUIDs or
+// the targets with the given addresses.
+func (p *Prober) Forget(ids ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, id := range ids {
+		delete(p.statuses, id)
+	}
+}
+
+// Targets resolves the targets of the referenced instances.
+func (p *Prober) Targets(ctx context.Context, client instances.InstancesService, ids ...kcclient.Ref) ([]Target, error) {
+	resp, err := client.Get(ctx, ids...)
+	if err != nil {
+		return nil, fmt.Errorf("getting instances: %w", err)
+	}
+
+	items, err := resp.AllOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("getting instances: %w", err)
+	}
+
+	targets := make([]Target, 0, len(items))
+	for i := range items {
+		target, err := p.resolver(&items[i])
+		if err != nil {
+			return nil, fmt.Errorf("resolving target of instance '%s': %w", items[i].Name, err)
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// WaitReady checks the referenced instances until all of them are ready, or
+// returns the last failure of a target which is not ready once the context is
+// done.
+func (p *Prober) WaitReady(ctx context.Context, client instances.InstancesService, ids ...kcclient.Ref) error {
+	targets, err := p.Targets(ctx, client, ids...)
+	if err != nil {
+		return err
+	}
+
+	return p.WaitTargets(ctx, targets...)
+}
+
+// WaitTargets checks the targets until all of them are ready, or returns the
+// last failure of a target which is not ready once the context is done.
+func (p *Prober) WaitTargets(ctx context.Context, targets ...Target) error {
+	runCtx, cancel := context.WithCancel(ctx)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.Run(runCtx, targets...)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	for {
+		p.mu.Lock()
+		notify := p.notify
+		p.mu.Unlock()
+
+		errs := p.pending(targets)
+		if len(errs) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			errs = p.pending(targets)
+			if len(errs) == 0 {
+				return nil
+			}
+			return fmt.Errorf("waiting for targets to become ready: %w", errors.Join(append([]error{ctx.Err()}, errs...)...))
+		case <-notify:
+		}
+	}
+}
+
+// pending returns an error for every target which is not ready.
+func (p *Prober) pending(targets []Target) []error {
+	var errs []error
+	for _, target := range targets {
+		status := p.Status(target.key())
+		if status.State == StateReady {
+			continue
+		}
+
+		err := status.Last.Err
+		if err == nil {
+			err = fmt.Errorf("state is '%s'", status.State)
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", target.Address, err))
+	}
+
+	return errs
+}
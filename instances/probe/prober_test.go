@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package probe_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/instances/probe"
+	"sdk.kraft.cloud/services"
+)
+
+const uuid1 = "00000000-0000-0000-0000-000000000001"
+
+func TestProberThresholds(t *testing.T) {
+	var healthy atomic.Bool
+
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" || r.Host != "app.example.com" {
+			http.NotFound(w, r)
+			return
+		}
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(app.Close)
+
+	prober := probe.NewProber(probe.Probe{
+		Scheme:           "http",
+		Path:             "/healthz",
+		ExpectedStatus:   http.StatusNoContent,
+		SuccessThreshold: 2,
+		FailureThreshold: 2,
+	})
+
+	target := probe.Target{
+		UUID:    uuid1,
+		Address: app.Listener.Addr().String(),
+		Host:    "app.example.com",
+	}
+
+	ctx := context.Background()
+
+	steps := []struct {
+		healthy bool
+		want    probe.State
+	}{
+		{false, probe.StateUnknown},
+		{false, probe.StateNotReady},
+		{true, probe.StateNotReady},
+		{true, probe.StateReady},
+		{false, probe.StateReady},
+		{true, probe.StateReady},
+		{false, probe.StateReady},
+		{false, probe.StateNotReady},
+	}
+
+	for i, step := range steps {
+		healthy.Store(step.healthy)
+
+		status := prober.Record(ctx, target)
+		if status.State != step.want {
+			t.Fatalf("step %d: expected state %q, got %q (last error: %v)", i, step.want, status.State, status.Last.Err)
+		}
+		if status.Last.Success() != step.healthy {
+			t.Fatalf("step %d: expected success %t, got error %v", i, step.healthy, status.Last.Err)
+		}
+	}
+
+	if prober.Ready(uuid1) {
+		t.Fatal("expected target not to be ready")
+	}
+}
+
+func TestProberTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	prober := probe.NewProber(probe.Probe{Kind: probe.KindTCP})
+
+	if res := prober.Check(context.Background(), probe.Target{Address: addr}); !res.Success() {
+		t.Fatalf("expected check to succeed, got %v", res.Err)
+	}
+
+	_ = ln.Close()
+
+	if res := prober.Check(context.Background(), probe.Target{Address: addr}); res.Success() {
+		t.Fatal("expected check to fail after closing the listener")
+	}
+}
+
+func TestProberWaitReady(t *testing.T) {
+	var requests atomic.Int32
+
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Become ready on the third request.
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(app.Close)
+
+	host, port, err := net.SplitHostPort(app.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/instances" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"instances":[{"uuid":%q,"name":"app","state":"running",`+
+			`"private_ip":%q,"private_fqdn":"app.internal"}]}}`, uuid1, host)
+	}))
+	t.Cleanup(api.Close)
+
+	var p int
+	if _, err := fmt.Sscan(port, &p); err != nil {
+		t.Fatal(err)
+	}
+
+	prober := probe.NewProber(
+		probe.Probe{
+			Scheme:   "http",
+			Interval: 10 * time.Millisecond,
+		},
+		probe.WithResolver(probe.PrivateResolver(p)),
+	)
+
+	client := kraftcloud.NewInstancesClient().WithMetro(api.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := prober.WaitReady(ctx, client, kcclient.ByName("app")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := requests.Load(); got < 3 {
+		t.Fatalf("expected at least 3 requests, got %d", got)
+	}
+
+	// A target which never becomes ready reports its last failure.
+	requests.Store(-1 << 20)
+	prober.Forget(uuid1)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err = prober.WaitReady(ctx, client, kcclient.ByName("app"))
+	if err == nil || !strings.Contains(err.Error(), "unexpected status code 502") {
+		t.Fatalf("expected error with the last failure, got %v", err)
+	}
+}
+
+func TestServiceGroupResolver(t *testing.T) {
+	item := &instances.GetResponseItem{
+		UUID:        uuid1,
+		Name:        "app",
+		PrivateFQDN: "app.internal",
+		ServiceGroup: &instances.GetCreateResponseServiceGroup{
+			Domains: []services.GetCreateResponseDomain{{FQDN: "app.example.com"}},
+		},
+	}
+
+	target, err := probe.ServiceGroupResolver(443)(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.UUID != uuid1 || target.Address != "app.example.com:443" || target.Host != "app.example.com" {
+		t.Errorf("expected the instance to be reached through its service group domain, got %+v", target)
+	}
+
+	// Every instance is dialed individually, even though all instances of
+	// the group serve the same domain.
+	target, err = probe.PrivateServiceGroupResolver(8080)(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.UUID != uuid1 || target.Address != "app.internal:8080" || target.Host != "app.example.com" {
+		t.Errorf("expected the instance to be reached through its private FQDN, got %+v", target)
+	}
+
+	item.PrivateFQDN = ""
+	if _, err := probe.PrivateServiceGroupResolver(8080)(item); err == nil {
+		t.Error("expected an error for an instance without private FQDN")
+	}
+
+	item.ServiceGroup = nil
+	if _, err := probe.ServiceGroupResolver(443)(item); err == nil {
+		t.Error("expected an error for an instance without service group")
+	}
+}
+
+func TestProberRecordCancelled(t *testing.T) {
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(app.Close)
+
+	prober := probe.NewProber(probe.Probe{Scheme: "http", FailureThreshold: 1})
+	target := probe.Target{UUID: uuid1, Address: app.Listener.Addr().String()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// A check which is aborted because the context is done says nothing about
+	// the readiness of the target.
+	if status := prober.Record(ctx, target); status.State != probe.StateUnknown || status.Failures != 0 {
+		t.Errorf("expected the aborted check not to be recorded, got %+v", status)
+	}
+}
@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package promexport parses the instance metrics which KraftCloud serves in
+// the Prometheus text exposition format, e.g. as returned by the
+// PrometheusMetrics method of the instances service.
+package promexport
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package promexport_test
+
+import (
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"sdk.kraft.cloud/instances/promexport"
+)
+
+const (
+	uuid1 = "00000000-0000-0000-0000-000000000001"
+
+	metrics = `# HELP instance_rss_bytes Resident set size of the instance.
+# TYPE instance_rss_bytes gauge
+instance_rss_bytes{instance_uuid="` + uuid1 + `"} 1.048576e+06
+# HELP instance_requests_total Total number of requests to the instance.
+# TYPE instance_requests_total counter
+instance_requests_total{instance_uuid="` + uuid1 + `"} 42
+`
+)
+
+func TestParse(t *testing.T) {
+	families, err := promexport.Parse(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(families) != 2 {
+		t.Fatalf("expected 2 metric families, got %d", len(families))
+	}
+
+	rss := families["instance_rss_bytes"]
+	if rss == nil || rss.GetType() != dto.MetricType_GAUGE || rss.GetMetric()[0].GetGauge().GetValue() != 1048576 {
+		t.Errorf("unexpected instance_rss_bytes family %v", rss)
+	}
+
+	reqs := families["instance_requests_total"]
+	if reqs == nil || reqs.GetType() != dto.MetricType_COUNTER || reqs.GetMetric()[0].GetCounter().GetValue() != 42 {
+		t.Errorf("unexpected instance_requests_total family %v", reqs)
+	}
+	if labels := reqs.GetMetric()[0].GetLabel(); len(labels) != 1 || labels[0].GetValue() != uuid1 {
+		t.Errorf("expected the instance UUID label, got %v", labels)
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	if _, err := promexport.Parse(strings.NewReader("instance_rss_bytes{")); err == nil {
+		t.Error("expected an error for malformed metrics")
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package scaletozero
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+// Analyzer polls instances and records their standby entries, wake-up
+// latencies and request gaps.
+type Analyzer struct {
+	client instances.InstancesService
+
+	ids         []kcclient.Ref
+	interval    time.Duration
+	percentile  float64
+	minCooldown time.Duration
+	maxCooldown time.Duration
+	now         func() time.Time
+	onError     func(error)
+
+	mu           sync.Mutex
+	start        time.Time
+	end          time.Time
+	observations map[string]*observation
+}
+
+// observation is what the analyzer recorded about an instance.
+type observation struct {
+	name   string
+	config *instances.ScaleToZero
+
+	state        instances.InstanceState
+	startCount   uint
+	total        uint64
+	lastActivity time.Time
+
+	standbyEntries int
+	wakeups        int
+	bootTimes      []time.Duration
+	netTimes       []time.Duration
+	gaps           []time.Duration
+}
+
+// NewAnalyzer instantiates a new Analyzer which polls instances through the
+// given client.
+func NewAnalyzer(client instances.InstancesService, aopts ...AnalyzerOption) *Analyzer {
+	a := &Analyzer{
+		client:       client,
+		interval:     DefaultInterval,
+		percentile:   DefaultPercentile,
+		minCooldown:  DefaultMinCooldown,
+		maxCooldown:  DefaultMaxCooldown,
+		now:          time.Now,
+		observations: make(map[string]*observation),
+	}
+
+	for _, opt := range aopts {
+		opt(a)
+	}
+
+	if a.interval <= 0 {
+		a.interval = DefaultInterval
+	}
+	if a.percentile <= 0 || a.percentile > 1 {
+		a.percentile = DefaultPercentile
+	}
+
+	return a
+}
+
+// Run polls the instances at the configured interval until the context is
+// cancelled.  Failed polls are reported to the error handler and do not stop
+// the analysis.
+func (a *Analyzer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.Check(ctx); err != nil && ctx.Err() == nil && a.onError != nil {
+			a.onError(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Check polls the instances once and records the observations.
+func (a *Analyzer) Check(ctx context.Context) error {
+	var (
+		resp *kcclient.ServiceResponse[instances.GetResponseItem]
+		err  error
+	)
+
+	if len(a.ids) > 0 {
+		resp, err = a.client.Get(ctx, a.ids...)
+	} else {
+		resp, err = a.client.List(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("getting instances: %w", err)
+	}
+
+	items, err := resp.AllOrErr()
+	if err != nil && (len(items) == 0 || resp.Status == "error") {
+		return fmt.Errorf("getting instances: %w", err)
+	}
+
+	configs := make(map[string]*instances.ScaleToZero, len(items))
+	uuids := make([]string, 0, len(items))
+
+	for _, item := range items {
+		if item.Error != nil || item.UUID == "" {
+			continue
+		}
+
+		// Without an explicit selection, only instances which can scale to
+		// zero are of interest.
+		if len(a.ids) == 0 && !enabled(item.ScaleToZero) {
+			continue
+		}
+
+		configs[item.UUID] = item.ScaleToZero
+		uuids = append(uuids, item.UUID)
+	}
+
+	if len(uuids) == 0 {
+		return nil
+	}
+
+	metrics, err := a.client.Metrics(ctx, kcclient.UUIDs(uuids...)...)
+	if err != nil {
+		return fmt.Errorf("getting metrics: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if a.start.IsZero() {
+		a.start = now
+	}
+	a.end = now
+
+	for i := range metrics.Data.Entries {
+		item := &metrics.Data.Entries[i]
+		if item.Error != nil {
+			continue
+		}
+
+		a.observe(now, item, configs[item.UUID])
+	}
+
+	return nil
+}
+
+// enabled returns whether the scale to zero configuration lets the instance
+// enter standby.
+func enabled(config *instances.ScaleToZero) bool {
+	return config != nil &&
+		config.Policy != nil &&
+		*config.Policy != instances.ScaleToZeroPolicyOff
+}
+
+// observe updates the observation of the instance with its latest metrics.
+func (a *Analyzer) observe(now time.Time, item *instances.MetricsResponseItem, config *instances.ScaleToZero) {
+	obs, ok := a.observations[item.UUID]
+	if !ok {
+		// The first observation only establishes a baseline, since earlier
+		// wake-ups and requests cannot be placed in time.
+		a.observations[item.UUID] = &observation{
+			name:       item.Name,
+			config:     config,
+			state:      item.State,
+			startCount: item.StartCount,
+			total:      item.Total,
+		}
+		return
+	}
+
+	obs.name = item.Name
+	obs.config = config
+
+	if item.State == instances.InstanceStateStandby && obs.state != instances.InstanceStateStandby {
+		obs.standbyEntries++
+	}
+	obs.state = item.State
+
+	// Only the latest start is measured if several happened between two polls.
+	if item.StartCount > obs.startCount {
+		obs.wakeups += int(item.StartCount - obs.startCount)
+		if item.BootTimeUs > 0 {
+			obs.bootTimes = append(obs.bootTimes, time.Duration(item.BootTimeUs)*time.Microsecond)
+		}
+		if item.NetTimeUs > 0 {
+			obs.netTimes = append(obs.netTimes, time.Duration(item.NetTimeUs)*time.Microsecond)
+		}
+	}
+	obs.startCount = item.StartCount
+
+	if item.Total > obs.total {
+		if !obs.lastActivity.IsZero() {
+			obs.gaps = append(obs.gaps, now.Sub(obs.lastActivity))
+		}
+		obs.lastActivity = now
+	}
+	obs.total = item.Total
+}
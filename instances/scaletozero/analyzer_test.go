@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package scaletozero_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/instances/scaletozero"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+const (
+	uuid1 = "00000000-0000-0000-0000-000000000001"
+	uuid2 = "00000000-0000-0000-0000-000000000002"
+)
+
+type sample struct {
+	state      string
+	startCount int
+	bootTimeUs int
+	netTimeUs  int
+	total      int
+}
+
+// fakeInstances lists an instance with scale to zero enabled and one with
+// scale to zero disabled, and serves the current metrics sample of the former.
+type fakeInstances struct {
+	current sample
+}
+
+// serve registers the instance endpoints with the fake API.
+func (f *fakeInstances) serve(api *fakeapi.Server) {
+	api.Handle(http.MethodGet, "/instances", "instances", func(r *fakeapi.Request) []string {
+		return []string{
+			fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app","scale_to_zero":{"policy":"on","cooldown_time_ms":1000}}`, uuid1),
+			fmt.Sprintf(`{"status":"success","uuid":%q,"name":"db","scale_to_zero":{"policy":"off"}}`, uuid2),
+		}
+	})
+
+	api.Handle(http.MethodGet, "/instances/metrics", "instances", func(r *fakeapi.Request) []string {
+		s := f.current
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app",`+
+			`"state":%q,"start_count":%d,"boot_time_us":%d,"net_time_us":%d,"ntotal":%d}`,
+			uuid1, s.state, s.startCount, s.bootTimeUs, s.netTimeUs, s.total)}
+	})
+}
+
+func TestAnalyzer(t *testing.T) {
+	fake := &fakeInstances{}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	analyzer := scaletozero.NewAnalyzer(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		scaletozero.WithInterval(5*time.Second),
+		scaletozero.WithClock(func() time.Time { return now }),
+	)
+
+	samples := []sample{
+		{"running", 1, 2000, 6000, 10}, // baseline
+		{"running", 1, 2000, 6000, 12}, // requests
+		{"standby", 1, 2000, 6000, 12}, // enters standby
+		{"standby", 1, 2000, 6000, 12},
+		{"running", 2, 3000, 8000, 13}, // wakes up after a 15s gap
+		{"running", 2, 3000, 8000, 14}, // requests after a 5s gap
+		{"standby", 2, 3000, 8000, 14}, // enters standby again
+	}
+
+	for i, s := range samples {
+		api.Do(func() { fake.current = s })
+		if err := analyzer.Check(context.Background()); err != nil {
+			t.Fatalf("check %d: %v", i, err)
+		}
+		now = now.Add(5 * time.Second)
+	}
+
+	report := analyzer.Report()
+	if len(report.Instances) != 1 {
+		t.Fatalf("expected only the instance with scale to zero enabled, got %+v", report.Instances)
+	}
+
+	ir := report.Instances[0]
+	if ir.Name != "app" || ir.Policy != "on" || ir.Cooldown != time.Second {
+		t.Errorf("unexpected configuration: %+v", ir)
+	}
+	if ir.StandbyEntries != 2 {
+		t.Errorf("expected 2 standby entries, got %d", ir.StandbyEntries)
+	}
+	if ir.Wakeups != 1 {
+		t.Errorf("expected 1 wake-up, got %d", ir.Wakeups)
+	}
+	if ir.BootTime.Count != 1 || ir.BootTime.Max != 3*time.Millisecond {
+		t.Errorf("expected a boot time of 3ms, got %+v", ir.BootTime)
+	}
+	if ir.NetTime.Count != 1 || ir.NetTime.Max != 8*time.Millisecond {
+		t.Errorf("expected a net time of 8ms, got %+v", ir.NetTime)
+	}
+	if ir.RequestGaps.Count != 2 || ir.RequestGaps.Min != 5*time.Second || ir.RequestGaps.Max != 15*time.Second {
+		t.Errorf("expected request gaps of 5s and 15s, got %+v", ir.RequestGaps)
+	}
+	if ir.RecommendedCooldown != 15*time.Second {
+		t.Errorf("expected a recommended cooldown of 15s, got %s", ir.RecommendedCooldown)
+	}
+
+	var b strings.Builder
+	if err := report.WriteMarkdown(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "| app | on | 1s | 2 | 1 | 3ms / 3ms | 8ms / 8ms | 5s / 15s | 15s (") {
+		t.Errorf("unexpected Markdown report:\n%s", b.String())
+	}
+}
+
+func TestAnalyzerRunRetries(t *testing.T) {
+	fake := &fakeInstances{current: sample{state: "running", startCount: 1}}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	var polls int
+	retried := make(chan struct{})
+	api.Handle(http.MethodGet, "/instances", "instances", func(r *fakeapi.Request) []string {
+		polls++
+		switch polls {
+		case 1:
+			return []string{`{"status":"error","message":"timed out","error":12}`}
+		case 2:
+			close(retried)
+		}
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app","scale_to_zero":{"policy":"on"}}`, uuid1)}
+	})
+
+	errs := make(chan error, 1)
+
+	analyzer := scaletozero.NewAnalyzer(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		scaletozero.WithInterval(10*time.Millisecond),
+		scaletozero.WithErrorHandler(func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- analyzer.Run(ctx) }()
+
+	select {
+	case <-retried:
+	case err := <-done:
+		t.Fatalf("expected Run to keep polling after an error, got %v", err)
+	case <-ctx.Done():
+		t.Fatal("expected Run to poll again after an error")
+	}
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("expected the failed poll to be reported")
+		}
+	default:
+		t.Error("expected the failed poll to be reported")
+	}
+
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+}
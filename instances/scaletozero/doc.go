@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package scaletozero observes instances on KraftCloud which have scale to
+// zero enabled and reports how often they enter standby, how long they take
+// to wake up and which cooldown suits their traffic.
+package scaletozero
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package scaletozero
+
+import (
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+)
+
+const (
+	// DefaultInterval is the default interval at which instances are polled.
+	DefaultInterval = 5 * time.Second
+
+	// DefaultPercentile is the default share of observed request gaps which
+	// the recommended cooldown covers.
+	DefaultPercentile = 0.9
+
+	// DefaultMinCooldown is the default lower bound of the recommended
+	// cooldown.
+	DefaultMinCooldown = time.Second
+
+	// DefaultMaxCooldown is the default upper bound of the recommended
+	// cooldown.
+	DefaultMaxCooldown = time.Hour
+)
+
+// AnalyzerOption is an option function used during initialization of an
+// Analyzer.
+type AnalyzerOption func(*Analyzer)
+
+// WithInstances restricts the analyzer to the referenced instances.  By
+// default, all instances with scale to zero enabled are observed.
+func WithInstances(ids ...kcclient.Ref) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.ids = ids
+	}
+}
+
+// WithInterval sets the interval at which instances are polled.  Request gaps
+// and standby entries are only observed at this granularity, see
+// InstanceReport.RequestGaps.  Defaults to DefaultInterval if unset or not
+// positive.
+func WithInterval(interval time.Duration) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.interval = interval
+	}
+}
+
+// WithPercentile sets the share of observed request gaps, between 0 and 1,
+// which the recommended cooldown covers.  Defaults to DefaultPercentile if
+// out of range.
+func WithPercentile(p float64) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.percentile = p
+	}
+}
+
+// WithCooldownBounds sets the bounds of the recommended cooldown.
+func WithCooldownBounds(lower, upper time.Duration) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.minCooldown = lower
+		a.maxCooldown = upper
+	}
+}
+
+// WithClock sets the function returning the current time.
+func WithClock(now func() time.Time) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.now = now
+	}
+}
+
+// WithErrorHandler sets a function which is called with the error of every
+// failed poll of Run.  Run keeps polling after an error.
+func WithErrorHandler(fn func(error)) AnalyzerOption {
+	return func(a *Analyzer) {
+		a.onError = fn
+	}
+}
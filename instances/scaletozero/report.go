@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package scaletozero
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/internal/percentile"
+)
+
+// Stats summarizes a series of durations.
+type Stats struct {
+	Count int           `json:"count"`
+	Min   time.Duration `json:"min"`
+	Mean  time.Duration `json:"mean"`
+	P50   time.Duration `json:"p50"`
+	P95   time.Duration `json:"p95"`
+	Max   time.Duration `json:"max"`
+}
+
+// newStats summarizes the given durations.
+func newStats(samples []time.Duration) Stats {
+	if len(samples) == 0 {
+		return Stats{}
+	}
+
+	sorted := percentile.Sorted(samples)
+
+	var sum time.Duration
+	for _, s := range sorted {
+		sum += s
+	}
+
+	return Stats{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Mean:  sum / time.Duration(len(sorted)),
+		P50:   percentile.Of(sorted, 0.5),
+		P95:   percentile.Of(sorted, 0.95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// InstanceReport is the analysis of a single instance.
+type InstanceReport struct {
+	// UUID of the instance.
+	UUID string `json:"uuid"`
+
+	// Name of the instance.
+	Name string `json:"name"`
+
+	// Policy is the configured scale to zero policy.
+	Policy instances.ScaleToZeroPolicy `json:"policy,omitempty"`
+
+	// Stateful is true if the instance is snapshotted when scaled to zero.
+	Stateful bool `json:"stateful"`
+
+	// Cooldown is the configured cooldown.
+	Cooldown time.Duration `json:"cooldown"`
+
+	// StandbyEntries is the number of times the instance was observed to enter
+	// standby.
+	StandbyEntries int `json:"standby_entries"`
+
+	// Wakeups is the number of times the instance was started.
+	Wakeups int `json:"wakeups"`
+
+	// BootTime summarizes the time to finish booting across wake-ups.
+	BootTime Stats `json:"boot_time"`
+
+	// NetTime summarizes the time to the first listening socket across
+	// wake-ups.
+	NetTime Stats `json:"net_time"`
+
+	// RequestGaps summarizes the observed time between requests.  The metrics
+	// only expose the total number of requests, so gaps are measured between
+	// the polls at which new requests were observed.  They are thus multiples
+	// of the polling interval, may be off by up to one interval, and gaps
+	// shorter than the interval are not observed at all.
+	RequestGaps Stats `json:"request_gaps"`
+
+	// RecommendedCooldown is the cooldown which covers the configured share
+	// of request gaps.  It is zero if no gaps were observed.
+	RecommendedCooldown time.Duration `json:"recommended_cooldown"`
+
+	// Recommendation explains the recommended cooldown.
+	Recommendation string `json:"recommendation"`
+}
+
+// Report is the analysis of all observed instances.
+type Report struct {
+	// Start is the time of the first poll.
+	Start time.Time `json:"start"`
+
+	// End is the time of the last poll.
+	End time.Time `json:"end"`
+
+	// Interval is the polling interval.
+	Interval time.Duration `json:"interval"`
+
+	// Instances are the analyses of the instances, sorted by name.
+	Instances []InstanceReport `json:"instances"`
+}
+
+// Report returns the analysis of the observations so far.
+func (a *Analyzer) Report() *Report {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	report := &Report{
+		Start:     a.start,
+		End:       a.end,
+		Interval:  a.interval,
+		Instances: make([]InstanceReport, 0, len(a.observations)),
+	}
+
+	for uuid, obs := range a.observations {
+		ir := InstanceReport{
+			UUID:           uuid,
+			Name:           obs.name,
+			StandbyEntries: obs.standbyEntries,
+			Wakeups:        obs.wakeups,
+			BootTime:       newStats(obs.bootTimes),
+			NetTime:        newStats(obs.netTimes),
+			RequestGaps:    newStats(obs.gaps),
+		}
+
+		if obs.config != nil {
+			if obs.config.Policy != nil {
+				ir.Policy = *obs.config.Policy
+			}
+			if obs.config.Stateful != nil {
+				ir.Stateful = *obs.config.Stateful
+			}
+			if obs.config.CooldownTimeMs != nil {
+				ir.Cooldown = time.Duration(*obs.config.CooldownTimeMs) * time.Millisecond
+			}
+		}
+
+		ir.RecommendedCooldown, ir.Recommendation = a.recommend(obs, ir)
+
+		report.Instances = append(report.Instances, ir)
+	}
+
+	sort.Slice(report.Instances, func(i, j int) bool {
+		return report.Instances[i].Name < report.Instances[j].Name
+	})
+
+	return report
+}
+
+// recommend returns a cooldown which covers the configured share of request
+// gaps, together with its rationale.
+func (a *Analyzer) recommend(obs *observation, ir InstanceReport) (time.Duration, string) {
+	if len(obs.gaps) == 0 {
+		return 0, "not enough traffic observed"
+	}
+
+	sorted := percentile.Sorted(obs.gaps)
+
+	cooldown := percentile.Of(sorted, a.percentile)
+	cooldown = (cooldown + time.Second - 1).Truncate(time.Second)
+	cooldown = max(a.minCooldown, min(cooldown, a.maxCooldown))
+
+	rationale := fmt.Sprintf("covers %.0f%% of %d request gaps measured every %s", a.percentile*100, len(sorted), a.interval)
+	if ir.NetTime.Count > 0 {
+		rationale += fmt.Sprintf("; longer gaps incur a cold start of about %s", ir.NetTime.P95)
+	}
+
+	return cooldown, rationale
+}
+
+// WriteMarkdown renders the report as a Markdown table, e.g. for a CI job
+// summary.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "## Scale to zero report\n\nObserved from %s to %s every %s.\n\n",
+		r.Start.Format(time.RFC3339), r.End.Format(time.RFC3339), r.Interval); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(w,
+		"| Instance | Policy | Cooldown | Standby entries | Wake-ups | Boot time (p50/p95) | Net time (p50/p95) | Request gap (p50/p95) | Recommended cooldown |\n"+
+			"|---|---|---|---:|---:|---|---|---|---|\n"); err != nil {
+		return err
+	}
+
+	for _, ir := range r.Instances {
+		policy := string(ir.Policy)
+		if policy == "" {
+			policy = "-"
+		}
+		if ir.Stateful {
+			policy += " (stateful)"
+		}
+
+		recommended := "-"
+		if ir.RecommendedCooldown > 0 {
+			recommended = ir.RecommendedCooldown.String()
+		}
+
+		if _, err := fmt.Fprintf(w, "| %s | %s | %s | %d | %d | %s | %s | %s | %s (%s) |\n",
+			ir.Name,
+			policy,
+			ir.Cooldown,
+			ir.StandbyEntries,
+			ir.Wakeups,
+			formatStats(ir.BootTime),
+			formatStats(ir.NetTime),
+			formatStats(ir.RequestGaps),
+			recommended,
+			ir.Recommendation,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatStats formats the median and 95th percentile of the stats.
+func formatStats(s Stats) string {
+	if s.Count == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s / %s", s.P50, s.P95)
+}
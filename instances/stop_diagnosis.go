@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances
+
+import (
+	"fmt"
+	"syscall"
+)
+
+var _ fmt.Stringer = (*StopCodeReason)(nil)
+
+// String implements fmt.Stringer
+func (reason StopCodeReason) String() string {
+	reasons := StopCodeReasons()
+	if int(reason) >= len(reasons) {
+		return fmt.Sprintf("%d", uint8(reason))
+	}
+
+	return reasons[reason]
+}
+
+// StopClass is a coarse classification of why an instance stopped.
+type StopClass string
+
+const (
+	// StopClassUnknown indicates that the instance did not report any stop
+	// details, e.g. because it has never been stopped.
+	StopClassUnknown StopClass = "unknown"
+
+	// StopClassOOM indicates that the instance ran out of memory.
+	StopClassOOM StopClass = "oom"
+
+	// StopClassCrash indicates that the instance stopped due to a fault, e.g. a
+	// segmentation fault, an assertion or an arithmetic error.
+	StopClassCrash StopClass = "crash"
+
+	// StopClassErrorExit indicates that the application exited on its own
+	// with a non-zero exit code.
+	StopClassErrorExit StopClass = "error_exit"
+
+	// StopClassCleanExit indicates that the application exited on its own
+	// with a zero exit code.
+	StopClassCleanExit StopClass = "clean_exit"
+
+	// StopClassUserStop indicates that the instance was stopped on request of
+	// the user.
+	StopClassUserStop StopClass = "user_stop"
+
+	// StopClassPlatformStop indicates that the instance was stopped by the
+	// platform, e.g. when scaling to zero.
+	StopClassPlatformStop StopClass = "platform_stop"
+)
+
+// String implements fmt.Stringer
+func (class StopClass) String() string {
+	return string(class)
+}
+
+// StopDiagnosis is the decoded form of the stop code, stop reason and exit code
+// of an instance.
+type StopDiagnosis struct {
+	// Class is the classification of the stop.
+	Class StopClass `json:"class"`
+
+	// Reason is the kernel's reason for the stop.
+	Reason StopCodeReason `json:"reason"`
+
+	// Errno is the application errno at the time of the stop, or 0.
+	Errno syscall.Errno `json:"errno"`
+
+	// InitLevel is the initlevel at the time of the stop.
+	InitLevel uint8 `json:"init_level"`
+
+	// TermTable is true if the stop originated from the termtable rather than
+	// from the inittable.
+	TermTable bool `json:"term_table"`
+
+	// Origin are the flags describing who initiated the stop.
+	Origin StopReason `json:"origin"`
+
+	// ExitCode is the exit code of the application, if any.
+	ExitCode *uint `json:"exit_code,omitempty"`
+}
+
+// Forced returns whether the stop was forced.
+func (d StopDiagnosis) Forced() bool {
+	return d.Origin&StopReasonForced != 0
+}
+
+// Failed returns whether the stop is considered a failure of the instance.
+func (d StopDiagnosis) Failed() bool {
+	switch d.Class {
+	case StopClassOOM, StopClassCrash, StopClassErrorExit:
+		return true
+	default:
+		return false
+	}
+}
+
+// Diagnose decodes the stop details of the instance into a StopDiagnosis.
+func (item *GetResponseItem) Diagnose() StopDiagnosis {
+	d := StopDiagnosis{
+		Reason:    item.StopCodeReason(),
+		Errno:     syscall.Errno(item.StopCodeErrno()),
+		InitLevel: item.StopCodeInitLevel(),
+		TermTable: item.StopCodeShutdownTable() == 1,
+		ExitCode:  item.ExitCode,
+	}
+
+	if item.StopReason != nil {
+		d.Origin = *item.StopReason
+	}
+
+	d.Class = classifyStop(item.StopCode != nil, d)
+
+	return d
+}
+
+// classifyStop determines the StopClass of a decoded stop.  The kernel's
+// reason takes precedence over the origin of the stop, since a fault is what
+// ultimately ended the instance, regardless of who asked for the stop.
+func classifyStop(hasStopCode bool, d StopDiagnosis) StopClass {
+	if !hasStopCode && d.Origin == 0 {
+		return StopClassUnknown
+	}
+
+	switch {
+	case d.Reason == StopCodeReasonPGFAULT && d.Errno == syscall.ENOMEM:
+		return StopClassOOM
+	case d.Reason != StopCodeReasonOK:
+		return StopClassCrash
+	case d.Origin&StopReasonUser != 0:
+		return StopClassUserStop
+	case d.Origin&StopReasonPlatform != 0:
+		return StopClassPlatformStop
+	case d.ExitCode != nil && *d.ExitCode != 0:
+		return StopClassErrorExit
+	default:
+		return StopClassCleanExit
+	}
+}
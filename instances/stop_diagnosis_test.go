@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package instances_test
+
+import (
+	"syscall"
+	"testing"
+
+	"sdk.kraft.cloud/instances"
+)
+
+func TestDiagnose(t *testing.T) {
+	ptr := func(v uint) *uint { return &v }
+	reason := func(r instances.StopReason) *instances.StopReason { return &r }
+
+	tests := []struct {
+		name      string
+		item      instances.GetResponseItem
+		wantClass instances.StopClass
+		wantErrno syscall.Errno
+	}{
+		{
+			name:      "never stopped",
+			item:      instances.GetResponseItem{},
+			wantClass: instances.StopClassUnknown,
+		},
+		{
+			name: "out of memory",
+			item: instances.GetResponseItem{
+				StopCode:   ptr(0x0c0104),
+				StopReason: reason(instances.StopReasonKernel),
+			},
+			wantClass: instances.StopClassOOM,
+			wantErrno: syscall.ENOMEM,
+		},
+		{
+			name: "segmentation fault",
+			item: instances.GetResponseItem{
+				StopCode:   ptr(0x000105),
+				StopReason: reason(instances.StopReasonKernel),
+			},
+			wantClass: instances.StopClassCrash,
+		},
+		{
+			name: "user stop",
+			item: instances.GetResponseItem{
+				StopCode:   ptr(0x008100),
+				StopReason: reason(instances.StopReasonUser | instances.StopReasonPlatform),
+			},
+			wantClass: instances.StopClassUserStop,
+		},
+		{
+			name: "scale to zero",
+			item: instances.GetResponseItem{
+				StopCode:   ptr(0x008100),
+				StopReason: reason(instances.StopReasonPlatform),
+			},
+			wantClass: instances.StopClassPlatformStop,
+		},
+		{
+			name: "non-zero exit",
+			item: instances.GetResponseItem{
+				StopCode:   ptr(0x000100),
+				StopReason: reason(instances.StopReasonApplication),
+				ExitCode:   ptr(1),
+			},
+			wantClass: instances.StopClassErrorExit,
+		},
+		{
+			name: "clean exit",
+			item: instances.GetResponseItem{
+				StopCode:   ptr(0x000100),
+				StopReason: reason(instances.StopReasonApplication),
+				ExitCode:   ptr(0),
+			},
+			wantClass: instances.StopClassCleanExit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.item.Diagnose()
+			if d.Class != tt.wantClass {
+				t.Errorf("expected class %s, got %s", tt.wantClass, d.Class)
+			}
+			if d.Errno != tt.wantErrno {
+				t.Errorf("expected errno %v, got %v", tt.wantErrno, d.Errno)
+			}
+		})
+	}
+}
@@ -13,20 +13,15 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // CreateTemplate implements InstancesService.
-func (c *client) CreateTemplate(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[TemplateCreateResponseItem], error) {
+func (c *client) CreateTemplate(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[TemplateCreateResponseItem], error) {
 	var body []byte
 
 	reqItems := make([]map[string]string, 0, len(ids))
 	for _, id := range ids {
-		if uuid.IsValid(id) {
-			reqItems = append(reqItems, map[string]string{"uuid": id})
-		} else {
-			reqItems = append(reqItems, map[string]string{"name": id})
-		}
+		reqItems = append(reqItems, map[string]string{id.Attr(): id.Value()})
 	}
 
 	body, err := json.Marshal(reqItems)
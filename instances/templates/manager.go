@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+)
+
+// DefaultPollInterval is the default interval at which the manager polls the
+// state of a template which is being created.
+const DefaultPollInterval = time.Second
+
+// Version is a versioned instance template.
+type Version struct {
+	// Base is the name of the template without its version suffix.
+	Base string `json:"base"`
+
+	// Version is the version number of the template.
+	Version int `json:"version"`
+
+	// Template is the state of the template.
+	Template instances.TemplateGetResponseItem `json:"template"`
+}
+
+// VersionedName returns the name of version v of the template named base.
+func VersionedName(base string, v int) string {
+	return fmt.Sprintf("%s-v%d", base, v)
+}
+
+// Manager builds versioned instance templates from warmed-up instances and
+// creates fleets of instances from them.
+type Manager struct {
+	client       instances.InstancesService
+	pollInterval time.Duration
+}
+
+// ManagerOption is an option function used during initialization of a
+// Manager.
+type ManagerOption func(*Manager)
+
+// WithPollInterval sets the interval at which the manager polls the state of
+// a template which is being created.
+func WithPollInterval(interval time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.pollInterval = interval
+	}
+}
+
+// NewManager instantiates a new Manager which uses the given client.
+func NewManager(client instances.InstancesService, mopts ...ManagerOption) *Manager {
+	m := &Manager{
+		client:       client,
+		pollInterval: DefaultPollInterval,
+	}
+
+	for _, opt := range mopts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Build boots an instance from req, waits for it to become ready and turns it
+// into the next version of the template named base.  The name of the request
+// is overwritten with the versioned name.  If any step fails, the instance is
+// deleted again.
+func (m *Manager) Build(ctx context.Context, base string, req instances.CreateRequest, ready Readiness) (*Version, error) {
+	if base == "" {
+		return nil, errors.New("template name cannot be empty")
+	}
+
+	versions, err := m.Versions(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 1
+	if len(versions) > 0 {
+		next = versions[len(versions)-1].Version + 1
+	}
+
+	name := VersionedName(base, next)
+	req.Name = &name
+
+	autostart := true
+	req.Autostart = &autostart
+
+	resp, err := m.client.Create(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("creating instance: %w", err)
+	}
+
+	created, err := resp.FirstOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("creating instance: %w", err)
+	}
+
+	tmpl, err := m.convert(ctx, created.UUID, ready)
+	if err != nil {
+		// Use a fresh context, as the cause may be a cancelled context.
+		if _, derr := m.client.Delete(context.WithoutCancel(ctx), kcclient.ByUUID(created.UUID)); derr != nil {
+			err = errors.Join(err, fmt.Errorf("deleting instance: %w", derr))
+		}
+		return nil, err
+	}
+
+	return &Version{
+		Base:     base,
+		Version:  next,
+		Template: *tmpl,
+	}, nil
+}
+
+// convert waits for the instance to become ready, turns it into a template
+// and waits for the template to be available.
+func (m *Manager) convert(ctx context.Context, uuid string, ready Readiness) (*instances.TemplateGetResponseItem, error) {
+	if ready != nil {
+		if err := ready(ctx, m.client, uuid); err != nil {
+			return nil, fmt.Errorf("waiting for instance to become ready: %w", err)
+		}
+	}
+
+	resp, err := m.client.CreateTemplate(ctx, kcclient.ByUUID(uuid))
+	if err != nil {
+		return nil, fmt.Errorf("creating template: %w", err)
+	}
+	created, err := resp.FirstOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("creating template: %w", err)
+	}
+
+	for {
+		resp, err := m.client.GetTemplate(ctx, kcclient.ByUUID(created.UUID))
+		if err != nil {
+			return nil, fmt.Errorf("getting template: %w", err)
+		}
+
+		tmpl, err := resp.FirstOrErr()
+		if err == nil && tmpl.State == instances.InstanceStateTemplate {
+			return tmpl, nil
+		}
+		if err != nil && (tmpl == nil || !transient(tmpl.Error)) {
+			return nil, fmt.Errorf("getting template: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for template: %w", ctx.Err())
+		case <-time.After(m.pollInterval):
+		}
+	}
+}
+
+// transient reports whether the error code of a response entry may go away
+// when the request is retried, e.g. while the template is still being created.
+func transient(code *kcclient.APIHTTPError) bool {
+	return code != nil && (*code == kcclient.APIHTTPErrorTimedOut || *code == kcclient.APIHTTPErrorFailedWrongVMState)
+}
+
+// Spawn creates n instances from the referenced template.
+// The optional mutate function is called for every instance with its index
+// and can alter the request, e.g. to set a name or a service group.
+func (m *Manager) Spawn(ctx context.Context, template kcclient.Ref, n int, mutate func(int, *instances.CreateRequest)) ([]instances.CreateResponseItem, error) {
+	if n <= 0 {
+		return nil, errors.New("number of instances must be positive")
+	}
+
+	tmpl, err := m.client.GetTemplate(ctx, template)
+	if err != nil {
+		return nil, fmt.Errorf("getting template: %w", err)
+	}
+
+	item, err := tmpl.FirstOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("getting template: %w", err)
+	}
+	if item.State != instances.InstanceStateTemplate {
+		return nil, fmt.Errorf("'%s' is in state '%s', not a template", template, item.State)
+	}
+
+	var (
+		created []instances.CreateResponseItem
+		errs    []error
+	)
+
+	for i := 0; i < n; i++ {
+		uuid := item.UUID
+		req := instances.CreateRequest{
+			Template: &instances.CreateRequestTemplate{
+				UUID: &uuid,
+			},
+		}
+		if mutate != nil {
+			mutate(i, &req)
+		}
+
+		resp, err := m.client.Create(ctx, req)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("creating instance %d: %w", i, err))
+			continue
+		}
+
+		instance, err := resp.FirstOrErr()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("creating instance %d: %w", i, err))
+			continue
+		}
+
+		created = append(created, *instance)
+	}
+
+	return created, errors.Join(errs...)
+}
+
+// Versions returns all versions of the template named base, oldest first.
+func (m *Manager) Versions(ctx context.Context, base string) ([]Version, error) {
+	resp, err := m.client.ListTemplate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing templates: %w", err)
+	}
+
+	tmpls, err := resp.AllOrErr()
+	if err != nil {
+		return nil, fmt.Errorf("listing templates: %w", err)
+	}
+
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(base) + `-v(\d+)$`)
+
+	var versions []Version
+	for _, tmpl := range tmpls {
+		match := pattern.FindStringSubmatch(tmpl.Name)
+		if match == nil {
+			continue
+		}
+
+		v, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+
+		versions = append(versions, Version{
+			Base:     base,
+			Version:  v,
+			Template: tmpl,
+		})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+
+	return versions, nil
+}
+
+// Latest returns the most recent version of the template named base.
+func (m *Manager) Latest(ctx context.Context, base string) (*Version, error) {
+	versions, err := m.Versions(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no versions of template '%s' found", base)
+	}
+
+	return &versions[len(versions)-1], nil
+}
+
+// Prune deletes all but the keep most recent versions of the template named
+// base and returns the deleted versions.
+func (m *Manager) Prune(ctx context.Context, base string, keep int) ([]Version, error) {
+	if keep < 0 {
+		return nil, errors.New("number of versions to keep cannot be negative")
+	}
+
+	versions, err := m.Versions(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) <= keep {
+		return nil, nil
+	}
+
+	stale := versions[:len(versions)-keep]
+
+	uuids := make([]kcclient.Ref, 0, len(stale))
+	for _, v := range stale {
+		uuids = append(uuids, kcclient.ByUUID(v.Template.UUID))
+	}
+
+	resp, err := m.client.DeleteTemplate(ctx, uuids...)
+	if err != nil {
+		return nil, fmt.Errorf("deleting templates: %w", err)
+	}
+
+	deleted, err := resp.AllOrErr()
+
+	var pruned []Version
+	for _, v := range stale {
+		for _, d := range deleted {
+			if d.UUID == v.Template.UUID && d.Error == nil {
+				pruned = append(pruned, v)
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		return pruned, fmt.Errorf("deleting templates: %w", err)
+	}
+
+	return pruned, nil
+}
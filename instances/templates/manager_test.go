@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package templates_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/instances/templates"
+	"sdk.kraft.cloud/internal/fakeapi"
+)
+
+// fakeBuild serves a template "app-v1" and builds the next version from an
+// instance which passes through the scripted states.
+type fakeBuild struct {
+	waits     []string // states returned by successive waits
+	templates []string // entries returned by successive gets of the new template
+	deleted   []string
+}
+
+// instanceUUID is the UUID of the instance the template is built from.
+const instanceUUID = "00000000-0000-0000-0000-000000000001"
+
+// next returns the first element of the script and advances it, keeping the
+// last element.
+func next(script *[]string) string {
+	s := (*script)[0]
+	if len(*script) > 1 {
+		*script = (*script)[1:]
+	}
+	return s
+}
+
+// serve registers the instance and template endpoints with the fake API.
+func (f *fakeBuild) serve(api *fakeapi.Server) {
+	api.Handle(http.MethodGet, "/instances/templates", "templates", func(r *fakeapi.Request) []string {
+		if len(r.Items) == 0 {
+			return []string{`{"status":"success","uuid":"t1","name":"app-v1","state":"template"}`}
+		}
+		return []string{next(&f.templates)}
+	})
+	api.Handle(http.MethodPost, "/instances", "instances", func(r *fakeapi.Request) []string {
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app-v2","state":"starting"}`, instanceUUID)}
+	})
+	api.Handle(http.MethodGet, "/instances/wait", "instances", func(r *fakeapi.Request) []string {
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":"app-v2","state":%q}`, instanceUUID, next(&f.waits))}
+	})
+	api.Handle(http.MethodPost, "/instances/templates", "templates", func(r *fakeapi.Request) []string {
+		return []string{`{"status":"success","uuid":"t2","name":"app-v2","state":"pending"}`}
+	})
+	api.Handle(http.MethodDelete, "/instances", "instances", func(r *fakeapi.Request) []string {
+		f.deleted = append(f.deleted, r.String(0, "uuid"))
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q}`, instanceUUID)}
+	})
+}
+
+func TestBuild(t *testing.T) {
+	fake := &fakeBuild{
+		waits: []string{"starting", "running"},
+		templates: []string{
+			`{"status":"error","message":"timed out","error":12}`,
+			`{"status":"success","uuid":"t2","name":"app-v2","state":"template"}`,
+		},
+	}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	manager := templates.NewManager(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		templates.WithPollInterval(time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	version, err := manager.Build(ctx, "app", instances.CreateRequest{}, templates.ReadyOnState(instances.StateRunning))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Version != 2 || version.Template.UUID != "t2" {
+		t.Errorf("expected version 2 of the template, got %+v", version)
+	}
+	if len(fake.deleted) != 0 {
+		t.Errorf("expected the instance to be kept as template, got deletions %v", fake.deleted)
+	}
+}
+
+func TestBuildTemplateError(t *testing.T) {
+	fake := &fakeBuild{
+		waits:     []string{"running"},
+		templates: []string{`{"status":"error","message":"not found","error":8}`},
+	}
+	api := fakeapi.New(t)
+	fake.serve(api)
+
+	manager := templates.NewManager(
+		kraftcloud.NewInstancesClient().WithMetro(api.URL),
+		templates.WithPollInterval(time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := manager.Build(ctx, "app", instances.CreateRequest{}, nil)
+	if err == nil || ctx.Err() != nil {
+		t.Fatalf("expected the build to fail on the template error before the deadline, got %v", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != instanceUUID {
+		t.Errorf("expected the instance to be deleted, got %v", fake.deleted)
+	}
+}
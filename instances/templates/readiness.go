@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package templates
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/instances/probe"
+)
+
+const (
+	// minStateBackoff and maxStateBackoff bound the time ReadyOnState waits
+	// before it waits again for an instance which is in another state.
+	minStateBackoff = 100 * time.Millisecond
+	maxStateBackoff = 5 * time.Second
+)
+
+// Readiness blocks until the instance with the given UUID signals that it is
+// ready to be turned into a template, or returns an error if it never does.
+type Readiness func(ctx context.Context, client instances.InstancesService, uuid string) error
+
+// ReadyOnState returns a Readiness which waits for the instance to reach the
+// given state.
+func ReadyOnState(state instances.State) Readiness {
+	return func(ctx context.Context, client instances.InstancesService, uuid string) error {
+		backoff := minStateBackoff
+
+		for {
+			resp, err := client.Wait(ctx, state, instances.DefaultWaitTimeoutMs, kcclient.ByUUID(uuid))
+			if err != nil {
+				return fmt.Errorf("waiting for state '%s': %w", state, err)
+			}
+
+			item, err := resp.FirstOrErr()
+			if err == nil && instances.State(item.State) == state {
+				return nil
+			}
+
+			// Only a timeout of the server-side wait is retried right away,
+			// until the context is done.
+			timedOut := item != nil && item.Error != nil && *item.Error == kcclient.APIHTTPErrorTimedOut
+			if err != nil && !timedOut {
+				return fmt.Errorf("waiting for state '%s': %w", state, err)
+			}
+			if ctx.Err() != nil {
+				return fmt.Errorf("waiting for state '%s': %w", state, ctx.Err())
+			}
+			if timedOut {
+				continue
+			}
+
+			// The wait returned early with another state, so back off before
+			// waiting again.
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("waiting for state '%s': %w", state, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxStateBackoff)
+		}
+	}
+}
+
+// ReadyOnProbe returns a Readiness which waits for the application in the
+// instance to pass the checks of the prober.
+func ReadyOnProbe(prober *probe.Prober) Readiness {
+	return func(ctx context.Context, client instances.InstancesService, uuid string) error {
+		return prober.WaitReady(ctx, client, kcclient.ByUUID(uuid))
+	}
+}
+
+// ReadyOnLog returns a Readiness which polls the console output of the
+// instance at the given interval until a line matches the pattern.
+func ReadyOnLog(pattern *regexp.Regexp, interval time.Duration) Readiness {
+	return func(ctx context.Context, client instances.InstancesService, uuid string) error {
+		var (
+			offset  int
+			partial string
+		)
+
+		for {
+			resp, err := client.Log(ctx, kcclient.ByUUID(uuid), offset, instances.LogMaxPageSize)
+			if err != nil {
+				return fmt.Errorf("reading console output: %w", err)
+			}
+
+			item, err := resp.FirstOrErr()
+			if err != nil {
+				return fmt.Errorf("reading console output: %w", err)
+			}
+
+			output, err := base64.StdEncoding.DecodeString(item.Output)
+			if err != nil {
+				return fmt.Errorf("decoding console output: %w", err)
+			}
+
+			offset = item.Range.End
+
+			// Only match complete lines and carry the remainder over to the
+			// next page.
+			lines := strings.Split(partial+string(output), "\n")
+			partial = lines[len(lines)-1]
+			for _, line := range lines[:len(lines)-1] {
+				if pattern.MatchString(line) {
+					return nil
+				}
+			}
+
+			if len(output) == instances.LogMaxPageSize {
+				continue
+			}
+
+			if instances.State(item.State) == instances.StateStopped {
+				return fmt.Errorf("instance stopped before printing a line matching '%s'", pattern)
+			}
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("waiting for a line matching '%s': %w", pattern, ctx.Err())
+			case <-time.After(interval):
+			}
+		}
+	}
+}
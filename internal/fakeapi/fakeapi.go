@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package fakeapi provides a fake KraftCloud API for tests, which serves the
+// responses of handlers registered by method and path.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Request is a request to the fake API.
+type Request struct {
+	*http.Request
+
+	// Body is the raw body of the request.
+	Body []byte
+
+	// Items are the elements of the body if it is a JSON array of objects,
+	// which is how most endpoints accept identifiers.
+	Items []map[string]any
+}
+
+// String returns the string attribute of the i-th item, or an empty string.
+func (r *Request) String(i int, attr string) string {
+	if i >= len(r.Items) {
+		return ""
+	}
+
+	s, _ := r.Items[i][attr].(string)
+	return s
+}
+
+// Handler returns the data entries of the response to the request, each a
+// JSON object.  Entries whose status is "error" make the response an error or
+// a partial success, like the API does.
+type Handler func(r *Request) []string
+
+// Server is a fake KraftCloud API.  Handlers run one at a time while holding
+// the lock of the server, so they can share state with the test through Do.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]route
+}
+
+// route is a registered handler and the key of the entries in its response.
+type route struct {
+	key string
+	fn  Handler
+}
+
+// New starts a fake API which is closed when the test completes.
+func New(tb testing.TB) *Server {
+	s := &Server{handlers: make(map[string]route)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// Handle registers the handler of requests with the given method and path,
+// whose entries are returned in the data of the response under key, e.g.
+// "instances".
+func (s *Server) Handle(method, path, key string, fn Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[method+" "+path] = route{key: key, fn: fn}
+}
+
+// Do runs fn while no handler is running.
+func (s *Server) Do(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn()
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rt, ok := s.handlers[r.Method+" "+r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &Request{Request: r, Body: body}
+	_ = json.Unmarshal(body, &req.Items)
+
+	entries := rt.fn(req)
+
+	failed := 0
+	for _, entry := range entries {
+		var common struct{ Status string }
+		if json.Unmarshal([]byte(entry), &common) == nil && common.Status == "error" {
+			failed++
+		}
+	}
+
+	status, message := "success", ""
+	switch {
+	case failed > 0 && failed == len(entries):
+		status, message = "error", "all entries failed"
+	case failed > 0:
+		status, message = "partial_success", fmt.Sprintf("%d of %d entries failed", failed, len(entries))
+	}
+
+	fmt.Fprintf(w, `{"status":%q,"message":%q,"data":{%q:[%s]}}`, status, message, rt.key, strings.Join(entries, ","))
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Domain is a domain of a service group.
+type Domain struct {
+	FQDN string
+
+	// Certificate is the UUID of the certificate securing the domain, if any.
+	// The certificate is named after its UUID.
+	Certificate string
+}
+
+// ServiceGroup is a service group whose domains can be attached to other
+// certificates.
+type ServiceGroup struct {
+	UUID    string
+	Name    string
+	Domains []Domain
+
+	// Patches are the received patch operations, in order.
+	Patches []map[string]any
+
+	// IgnorePatches makes patches succeed without being applied.
+	IgnorePatches bool
+}
+
+// ServeServiceGroup serves the service group from GET /services and applies
+// the certificates set through PATCH /services to its domains.
+func (s *Server) ServeServiceGroup(g *ServiceGroup) {
+	s.Handle(http.MethodGet, "/services", "service_groups", func(r *Request) []string {
+		if len(r.Items) == 0 {
+			return []string{fmt.Sprintf(`{"uuid":%q,"name":%q}`, g.UUID, g.Name)}
+		}
+
+		var domains []string
+		for _, d := range g.Domains {
+			if d.Certificate == "" {
+				domains = append(domains, fmt.Sprintf(`{"fqdn":%q}`, d.FQDN))
+				continue
+			}
+
+			domains = append(domains, fmt.Sprintf(`{"fqdn":%q,"certificate":{"uuid":%q,"name":%[2]q,"state":"valid"}}`,
+				d.FQDN, d.Certificate))
+		}
+
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q,"domains":[%s]}`,
+			g.UUID, g.Name, strings.Join(domains, ","))}
+	})
+
+	s.Handle(http.MethodPatch, "/services", "service_groups", func(r *Request) []string {
+		g.Patches = append(g.Patches, r.Items...)
+
+		for _, item := range r.Items {
+			value, ok := item["value"].(map[string]any)
+			if !ok || g.IgnorePatches {
+				continue
+			}
+
+			for i := range g.Domains {
+				if g.Domains[i].FQDN == item["id"] {
+					g.Domains[i].Certificate, _ = value["uuid"].(string)
+				}
+			}
+		}
+
+		return []string{fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q}`, g.UUID, g.Name)}
+	})
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package percentile computes nearest-rank percentiles of durations, so that
+// all reports of the SDK agree on their definition.
+package percentile
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Sorted returns a sorted copy of the durations.
+func Sorted(durations []time.Duration) []time.Duration {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// Of returns the nearest-rank percentile p, between 0 and 1, of the sorted
+// durations.  It returns zero if there are no durations.
+func Of(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package validation collects the errors of invalid fields of requests, so
+// that all of them are reported at once before any request is sent.
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is the error of a single field of a request.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. services[1].handlers.
+	Field string
+
+	// Message describes why the value of the field is invalid.
+	Message string
+}
+
+// Error implements error.
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Error contains the errors of all invalid fields of a request.
+type Error struct {
+	// Subject is what was validated, e.g. "service group".
+	Subject string
+
+	Errors []*FieldError
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return "invalid " + e.Subject + ": " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the fields.
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// Validator collects the errors of the fields of a request.
+type Validator struct {
+	subject string
+	errs    []*FieldError
+}
+
+// NewValidator returns a Validator for the given subject.
+func NewValidator(subject string) *Validator {
+	return &Validator{subject: subject}
+}
+
+// Addf adds an error of the field, formatted according to the format.
+func (v *Validator) Addf(field, format string, args ...any) {
+	v.errs = append(v.errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Err returns an *Error with all collected errors, or nil if there are none.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return &Error{Subject: v.subject, Errors: v.errs}
+}
@@ -0,0 +1,250 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/client/httpclient"
+)
+
+// DefaultCatalogTTL is the default time for which a cached catalog is valid.
+const DefaultCatalogTTL = time.Hour
+
+// CatalogEntry describes a metro known to a catalog.
+type CatalogEntry struct {
+	// Code is the code of the metro, e.g. fra0.
+	Code string `json:"code"`
+
+	// Location is the human-readable location of the metro.
+	Location string `json:"location,omitempty"`
+
+	// Proxy is the DNS name of the proxy of the metro.  It defaults to
+	// <code>.kraft.host.
+	Proxy string `json:"proxy,omitempty"`
+
+	// API is the base URL of the API of the metro.  It defaults to
+	// https://api.<code>.kraft.cloud.
+	API string `json:"api,omitempty"`
+
+	kcclient.APIResponseCommon
+}
+
+// proxy returns the DNS name of the proxy of the metro.
+func (e CatalogEntry) proxy() string {
+	if e.Proxy != "" {
+		return e.Proxy
+	}
+
+	return e.Code + ".kraft.host"
+}
+
+// api returns the base URL of the API of the metro.
+func (e CatalogEntry) api() string {
+	if e.API != "" {
+		return e.API
+	}
+
+	return "https://api." + e.Code + ".kraft.cloud"
+}
+
+// Catalog discovers the metros which are available.
+type Catalog interface {
+	// Metros returns the metros of the catalog.
+	Metros(ctx context.Context) ([]CatalogEntry, error)
+}
+
+// CatalogFunc is a function which implements Catalog.
+type CatalogFunc func(ctx context.Context) ([]CatalogEntry, error)
+
+// Metros implements Catalog.
+func (f CatalogFunc) Metros(ctx context.Context) ([]CatalogEntry, error) {
+	return f(ctx)
+}
+
+// DefaultCatalog contains the well-known metros.  It is the catalog of clients
+// unless set otherwise, and used when the metros cannot be discovered from the
+// API.
+var DefaultCatalog = StaticCatalog(
+	CatalogEntry{Code: "fra0", Location: "Frankfurt, DE", Proxy: "fra0.kraft.host"},
+	CatalogEntry{Code: "dal0", Location: "Dallas, TX", Proxy: "dal0.kraft.host"},
+	CatalogEntry{Code: "sin0", Location: "Singapore", Proxy: "sin0.kraft.host"},
+	CatalogEntry{Code: "was1", Location: "Washington, DC", Proxy: "was1.kraft.host"},
+)
+
+// StaticCatalog returns a catalog which contains the given metros.
+func StaticCatalog(entries ...CatalogEntry) Catalog {
+	return CatalogFunc(func(context.Context) ([]CatalogEntry, error) {
+		return append([]CatalogEntry(nil), entries...), nil
+	})
+}
+
+// ParseCatalog parses a catalog encoded as a JSON array of entries.
+func ParseCatalog(r io.Reader) ([]CatalogEntry, error) {
+	var entries []CatalogEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("parsing catalog: %w", err)
+	}
+
+	for i, entry := range entries {
+		if entry.Code == "" {
+			return nil, fmt.Errorf("parsing catalog: entry %d has no code", i)
+		}
+	}
+
+	return entries, nil
+}
+
+// FileCatalog returns a catalog which is read from the file at the given
+// path on every call.  See ParseCatalog for the format.
+func FileCatalog(path string) Catalog {
+	return CatalogFunc(func(context.Context) ([]CatalogEntry, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("opening catalog: %w", err)
+		}
+		defer f.Close()
+
+		return ParseCatalog(f)
+	})
+}
+
+// URLCatalog returns a catalog which is downloaded from the given URL with the
+// given HTTP client on every call.  A nil client uses the default HTTP client,
+// which honors the proxy environment variables.  See ParseCatalog for the
+// format.
+func URLCatalog(hc httpclient.HTTPClient, url string) Catalog {
+	if hc == nil {
+		hc = httpclient.NewHTTPClient()
+	}
+
+	return CatalogFunc(func(ctx context.Context) ([]CatalogEntry, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating the request: %w", err)
+		}
+
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := hc.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("performing the request: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("downloading catalog: unexpected status %s", resp.Status)
+		}
+
+		return ParseCatalog(resp.Body)
+	})
+}
+
+// APICatalog returns a catalog which is discovered from the metros endpoint of
+// the API using the given request.
+func APICatalog(request *kcclient.ServiceRequest) Catalog {
+	return CatalogFunc(func(ctx context.Context) ([]CatalogEntry, error) {
+		var resp kcclient.ServiceResponse[CatalogEntry]
+		if err := request.DoRequest(ctx, http.MethodGet, Endpoint, nil, &resp); err != nil {
+			return nil, fmt.Errorf("performing the request: %w", err)
+		}
+
+		entries, err := resp.AllOrErr()
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			return nil, errors.New("no metros returned")
+		}
+
+		return entries, nil
+	})
+}
+
+// FallbackCatalog returns a catalog which returns the metros of the first of
+// the given catalogs which succeeds.
+func FallbackCatalog(catalogs ...Catalog) Catalog {
+	return CatalogFunc(func(ctx context.Context) ([]CatalogEntry, error) {
+		var errs []error
+		for _, catalog := range catalogs {
+			entries, err := catalog.Metros(ctx)
+			if err == nil {
+				return entries, nil
+			}
+			errs = append(errs, err)
+
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		return nil, errors.Join(errs...)
+	})
+}
+
+// CachedCatalog caches the metros of a catalog.  When the catalog fails after
+// the cache expired, the expired metros are returned instead.
+type CachedCatalog struct {
+	catalog Catalog
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries []CatalogEntry
+	expires time.Time
+}
+
+var _ Catalog = (*CachedCatalog)(nil)
+
+// NewCachedCatalog instantiates a new CachedCatalog which caches the metros of
+// the given catalog for the given time.
+func NewCachedCatalog(catalog Catalog, ttl time.Duration) *CachedCatalog {
+	return &CachedCatalog{
+		catalog: catalog,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// Metros implements Catalog.
+func (c *CachedCatalog) Metros(ctx context.Context) ([]CatalogEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries != nil && c.now().Before(c.expires) {
+		return append([]CatalogEntry(nil), c.entries...), nil
+	}
+
+	entries, err := c.catalog.Metros(ctx)
+	if err != nil {
+		if c.entries != nil {
+			return append([]CatalogEntry(nil), c.entries...), nil
+		}
+		return nil, err
+	}
+
+	c.entries = entries
+	c.expires = c.now().Add(c.ttl)
+
+	return append([]CatalogEntry(nil), entries...), nil
+}
+
+// Invalidate discards the cached metros.
+func (c *CachedCatalog) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+}
@@ -6,6 +6,9 @@
 package metros
 
 import (
+	"context"
+	"crypto/tls"
+	"net"
 	"time"
 
 	kcclient "sdk.kraft.cloud/client"
@@ -17,8 +20,25 @@ import (
 //
 // See: https://docs.kraft.cloud/api/v1/metros/
 type client struct {
-	// constructors must ensure that request is non-nil
-	request *kcclient.ServiceRequest
+	// constructors must ensure that request, catalog, resolver and dialer are
+	// non-nil
+	request  *kcclient.ServiceRequest
+	catalog  Catalog
+	resolver Resolver
+	dialer   Dialer
+
+	// tlsConfig is used for TLS handshakes with metros if non-nil.
+	tlsConfig *tls.Config
+}
+
+// Resolver looks up the IP addresses of a host, e.g. *net.Resolver.
+type Resolver interface {
+	LookupIP(ctx context.Context, network, host string) ([]net.IP, error)
+}
+
+// Dialer dials network connections, e.g. *net.Dialer.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
 }
 
 var _ MetrosService = (*client)(nil)
@@ -27,7 +47,10 @@ var _ MetrosService = (*client)(nil)
 // the provided pre-existing options.
 func NewMetrosClientFromOptions(opts *options.Options) MetrosService {
 	return &client{
-		request: kcclient.NewServiceRequestFromDefaultOptions(opts),
+		request:  kcclient.NewServiceRequestFromDefaultOptions(opts),
+		catalog:  DefaultCatalog,
+		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{},
 	}
 }
 
@@ -53,6 +76,41 @@ func (c *client) WithTimeout(to time.Duration) MetrosService {
 	return ccpy
 }
 
+// WithCatalog sets the catalog from which the metros are discovered.
+func (c *client) WithCatalog(catalog Catalog) MetrosService {
+	ccpy := c.clone()
+	ccpy.catalog = catalog
+	return ccpy
+}
+
+// WithAPICatalog makes the client discover the metros from the API.
+func (c *client) WithAPICatalog() MetrosService {
+	ccpy := c.clone()
+	ccpy.catalog = NewCachedCatalog(FallbackCatalog(APICatalog(c.request), DefaultCatalog), DefaultCatalogTTL)
+	return ccpy
+}
+
+// WithResolver sets the resolver which looks up the IP addresses of metros.
+func (c *client) WithResolver(resolver Resolver) MetrosService {
+	ccpy := c.clone()
+	ccpy.resolver = resolver
+	return ccpy
+}
+
+// WithDialer sets the dialer which measures the delay to metros.
+func (c *client) WithDialer(dialer Dialer) MetrosService {
+	ccpy := c.clone()
+	ccpy.dialer = dialer
+	return ccpy
+}
+
+// WithTLSConfig sets the configuration of the TLS handshakes with metros.
+func (c *client) WithTLSConfig(config *tls.Config) MetrosService {
+	ccpy := c.clone()
+	ccpy.tlsConfig = config
+	return ccpy
+}
+
 // clone returns a shallow copy of c.
 func (c *client) clone() *client {
 	ccpy := *c
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"sdk.kraft.cloud/client/httpclient"
+)
+
+// failoverClient is an HTTP client which fails read-only requests over to the
+// next fastest metro when the metro of the request is unreachable.
+type failoverClient struct {
+	base     httpclient.HTTPClient
+	selector *Selector
+}
+
+// NewFailoverHTTPClient returns an HTTP client which performs requests with the
+// given client and fails read-only (GET and HEAD) requests over to the online
+// metros in the order of their delay when the metro of the request is
+// unreachable.  Unreachable metros are reported to the selector.
+//
+// Resources are local to their metro, so only requests for resources which
+// exist in every metro, e.g. images and quotas, are answered the same way
+// after a failover.
+//
+// Use it with the WithHTTPClient option of the client, e.g.:
+//
+//	selector := metros.NewSelector(kraftcloud.NewMetrosClient())
+//	client := kraftcloud.NewClient(
+//		kraftcloud.WithHTTPClient(metros.NewFailoverHTTPClient(httpclient.NewHTTPClient(), selector)),
+//	)
+func NewFailoverHTTPClient(base httpclient.HTTPClient, selector *Selector) httpclient.HTTPClient {
+	return &failoverClient{
+		base:     base,
+		selector: selector,
+	}
+}
+
+// Do implements httpclient.HTTPClient.
+func (c *failoverClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.base.Do(req)
+	if (req.Method != http.MethodGet && req.Method != http.MethodHead) || !unreachable(resp, err) {
+		return resp, err
+	}
+
+	ctx := req.Context()
+	if ctx.Err() != nil {
+		return resp, err
+	}
+
+	if _, herr := c.selector.Health(ctx); herr != nil {
+		return resp, err
+	}
+
+	primary := c.selector.metroOf(req.URL)
+	if primary == "" {
+		return resp, err
+	}
+
+	c.selector.ReportFailure(primary)
+
+	ranked, rerr := c.selector.Ranked(ctx)
+	if rerr != nil {
+		return resp, err
+	}
+
+	for _, h := range ranked {
+		if h.Code == primary {
+			continue
+		}
+
+		retry, rerr := redirect(req, h.API)
+		if rerr != nil {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		resp, err = c.base.Do(retry)
+		if !unreachable(resp, err) {
+			return resp, err
+		}
+
+		c.selector.ReportFailure(h.Code)
+	}
+
+	return resp, err
+}
+
+// unreachable reports whether a request failed to reach the metro.
+func unreachable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// redirect returns a copy of the request which is sent to the API with the
+// given base URL.
+func redirect(req *http.Request, api string) (*http.Request, error) {
+	base, err := url.Parse(api)
+	if err != nil {
+		return nil, fmt.Errorf("parsing API URL: %w", err)
+	}
+
+	retry := req.Clone(req.Context())
+	retry.URL.Scheme = base.Scheme
+	retry.URL.Host = base.Host
+	retry.Host = ""
+
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, fmt.Errorf("request body cannot be replayed")
+		}
+
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("replaying request body: %w", err)
+		}
+	}
+
+	return retry, nil
+}
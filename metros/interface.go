@@ -7,6 +7,7 @@ package metros
 
 import (
 	"context"
+	"crypto/tls"
 
 	kcclient "sdk.kraft.cloud/client"
 )
@@ -14,9 +15,30 @@ import (
 type MetrosService interface {
 	kcclient.ServiceClient[MetrosService]
 
-	// Lists all existing metros. This list is currently hard-coded so the
-	// returned result is always the same.
+	// Lists all existing metros as discovered from the catalog, which is
+	// DefaultCatalog unless set otherwise.
 	//
 	// See: https://docs.kraft.cloud/api/v1/metros/#list-existing-metros
 	List(ctx context.Context, status bool) ([]ListResponseItem, error)
+
+	// Probe probes all metros concurrently and measures the time of each step
+	// of reaching their API.
+	Probe(ctx context.Context) ([]ProbeResult, error)
+
+	// WithCatalog sets the catalog from which the metros are discovered.
+	WithCatalog(Catalog) MetrosService
+
+	// WithAPICatalog makes the client discover the metros from the API through
+	// its current metro, falling back to DefaultCatalog, and cache them for
+	// DefaultCatalogTTL.  The cache is shared by clients derived from it.
+	WithAPICatalog() MetrosService
+
+	// WithResolver sets the resolver which looks up the IP addresses of metros.
+	WithResolver(Resolver) MetrosService
+
+	// WithDialer sets the dialer which measures the delay to metros.
+	WithDialer(Dialer) MetrosService
+
+	// WithTLSConfig sets the configuration of the TLS handshakes with metros.
+	WithTLSConfig(*tls.Config) MetrosService
 }
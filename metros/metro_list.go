@@ -7,50 +7,20 @@ package metros
 
 import (
 	"context"
-	"net"
-	"net/http"
+	"fmt"
 	"sync"
 	"time"
 )
 
-// testMetroAlive sends a request to https://api.<metro>.kraft.cloud/ and checks
-// if a response is received is received and the time to dial the tcp connection.
-func testMetroAlive(metro, ip string) time.Duration {
-	url := "https://api." + metro + ".kraft.cloud/"
+// probeTimeout is the time after which a metro which does not respond is
+// considered offline.
+const probeTimeout = 3 * time.Second
 
-	client := http.Client{
-		Timeout: 3 * time.Second,
-	}
-
-	resp, err := client.Get(url)
-	if err != nil {
-		return 0
-	} else {
-		if resp != nil && resp.StatusCode == http.StatusUnauthorized {
-			address := ip + ":443"
-
-			start := time.Now()
-			conn, err := net.DialTimeout("tcp", address, 3*time.Second)
-			elapsed := time.Since(start)
-
-			if err != nil {
-				return 0
-			} else {
-				conn.Close()
-				return elapsed
-			}
-		} else {
-			return 0
-		}
-	}
-}
-
-// fillMetroIP looks up the IP address of the metro using the DNS name.
-func fillMetroIP(metro string) string {
-	url := metro + ".kraft.host"
-
-	ips, err := net.LookupIP(url)
-	if err != nil {
+// fillMetroIP looks up the IP address of the metro using the DNS name of its
+// proxy.
+func (c *client) fillMetroIP(ctx context.Context, entry CatalogEntry) string {
+	ips, err := c.resolver.LookupIP(ctx, "ip4", entry.proxy())
+	if err != nil || len(ips) == 0 {
 		return ""
 	}
 
@@ -59,44 +29,43 @@ func fillMetroIP(metro string) string {
 
 // List implements MetrosService.
 func (c *client) List(ctx context.Context, status bool) ([]ListResponseItem, error) {
-	items := []ListResponseItem{
-		{
-			Code:     "fra0",
-			Location: "Frankfurt, DE",
-			Proxy:    "fra0.kraft.host",
-		},
-		{
-			Code:     "dal0",
-			Location: "Dallas, TX",
-			Proxy:    "dal0.kraft.host",
-		},
-		{
-			Code:     "sin0",
-			Location: "Singapore",
-			Proxy:    "sin0.kraft.host",
-		},
-		{
-			Code:     "was1",
-			Location: "Washington, DC",
-			Proxy:    "was1.kraft.host",
-		},
+	entries, err := c.catalog.Metros(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("discovering metros: %w", err)
 	}
 
+	items := make([]ListResponseItem, len(entries))
+
 	var wg sync.WaitGroup
-	for i := range items {
+	for i, entry := range entries {
+		items[i] = ListResponseItem{
+			API:      entry.api(),
+			Code:     entry.Code,
+			Location: entry.Location,
+			Proxy:    entry.proxy(),
+		}
+
 		wg.Add(1)
-		go func(i int) {
-			items[i].Ipv4 = fillMetroIP(items[i].Code)
-			items[i].Online = items[i].Ipv4 != ""
+		go func(i int, entry CatalogEntry) {
+			defer wg.Done()
+
+			items[i].Ipv4 = c.fillMetroIP(ctx, entry)
 
-			if items[i].Online && status {
-				items[i].Delay = testMetroAlive(items[i].Code, items[i].Ipv4)
-				items[i].Online = items[i].Delay != 0
+			if !status {
+				items[i].Online = items[i].Ipv4 != ""
+				return
 			}
-			wg.Done()
-		}(i)
+
+			result := c.probe(ctx, entry)
+			items[i].Online = result.Up()
+			items[i].Delay = result.Timings.Connect
+		}(i, entry)
 	}
 	wg.Wait()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	return items, nil
 }
@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/metros"
+)
+
+type fakeResolver map[string]string
+
+func (r fakeResolver) LookupIP(_ context.Context, _, host string) ([]net.IP, error) {
+	ip, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return []net.IP{net.ParseIP(ip)}, nil
+}
+
+// fakeDialer dials the server of the dialed IP, or the dialed address itself
+// if it is a loopback address, after the delay of the IP or address.
+type fakeDialer struct {
+	servers map[string]*httptest.Server
+	delays  map[string]time.Duration
+}
+
+func (d fakeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, _ := net.SplitHostPort(address)
+	time.Sleep(d.delays[host] + d.delays[address])
+
+	if srv, ok := d.servers[host]; ok {
+		address = srv.Listener.Addr().String()
+	} else if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return nil, errors.New("connection refused")
+	}
+
+	return (&net.Dialer{}).DialContext(ctx, network, address)
+}
+
+// newAPI starts a fake API which rejects probes with the status returned by
+// the given function and otherwise serves its name.
+func newAPI(t *testing.T, name string, status func() int) *httptest.Server {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			w.WriteHeader(status())
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, name+":"+string(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// unauthorized is the status of a metro which is up.
+func unauthorized() int {
+	return http.StatusUnauthorized
+}
+
+// tlsConfig returns a configuration which trusts the fake APIs.
+func tlsConfig(srv *httptest.Server) *tls.Config {
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	return &tls.Config{RootCAs: pool}
+}
+
+func TestListOffline(t *testing.T) {
+	up := newAPI(t, "up", unauthorized)
+	down := newAPI(t, "down", func() int { return http.StatusBadGateway })
+
+	client := kraftcloud.NewMetrosClient().
+		WithHTTPClient(up.Client()).
+		WithTLSConfig(tlsConfig(up)).
+		WithCatalog(metros.StaticCatalog(
+			metros.CatalogEntry{Code: "up0", API: up.URL},
+			metros.CatalogEntry{Code: "down0", API: down.URL},
+			metros.CatalogEntry{Code: "gone0", Proxy: "gone0.example"},
+		)).
+		WithResolver(fakeResolver{
+			"up0.kraft.host":   "192.0.2.1",
+			"down0.kraft.host": "192.0.2.2",
+		}).
+		WithDialer(fakeDialer{
+			delays: map[string]time.Duration{up.Listener.Addr().String(): time.Millisecond},
+		})
+
+	items, err := client.List(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 3 {
+		t.Fatalf("expected 3 metros, got %d", len(items))
+	}
+	if items[0].Code != "up0" || !items[0].Online || items[0].Ipv4 != "192.0.2.1" || items[0].Delay == 0 {
+		t.Errorf("expected metro up0 to be online, got %+v", items[0])
+	}
+	if items[1].Code != "down0" || items[1].Online || items[1].Ipv4 != "192.0.2.2" {
+		t.Errorf("expected metro down0 to be offline, got %+v", items[1])
+	}
+	if items[2].Code != "gone0" || items[2].Online || items[2].Proxy != "gone0.example" {
+		t.Errorf("expected metro gone0 to be offline, got %+v", items[2])
+	}
+}
+
+func TestListDiscoversFromAPI(t *testing.T) {
+	var requests atomic.Int32
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metros" {
+			http.NotFound(w, r)
+			return
+		}
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"metros":[{"code":"api0","location":"Somewhere"}]}}`))
+	}))
+	defer api.Close()
+
+	client := kraftcloud.NewMetrosClient().
+		WithMetro(api.URL).
+		WithAPICatalog()
+
+	for i := range 2 {
+		// Derived clients share the cached catalog.
+		client = client.WithTimeout(time.Duration(i+1) * time.Second).
+			WithResolver(fakeResolver{"api0.kraft.host": "192.0.2.1"})
+
+		items, err := client.List(context.Background(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 1 || items[0].Code != "api0" || items[0].Location != "Somewhere" || !items[0].Online {
+			t.Fatalf("expected the metro discovered from the API, got %+v", items)
+		}
+	}
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected the discovered metros to be cached, got %d requests", n)
+	}
+}
+
+func TestListFallsBackToDefaultCatalog(t *testing.T) {
+	api := httptest.NewServer(http.NotFoundHandler())
+	defer api.Close()
+
+	items, err := kraftcloud.NewMetrosClient().
+		WithMetro(api.URL).
+		WithAPICatalog().
+		WithResolver(fakeResolver{}).
+		List(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := metros.DefaultCatalog.Metros(context.Background())
+	if len(items) != len(want) || items[0].Code != want[0].Code {
+		t.Errorf("expected the default metros, got %+v", items)
+	}
+}
+
+func TestListDefaultsToStaticCatalog(t *testing.T) {
+	var requests atomic.Int32
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer api.Close()
+
+	items, err := kraftcloud.NewMetrosClient().
+		WithMetro(api.URL).
+		WithResolver(fakeResolver{}).
+		List(context.Background(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := metros.DefaultCatalog.Metros(context.Background())
+	if len(items) != len(want) || requests.Load() != 0 {
+		t.Errorf("expected the default metros without discovery, got %+v after %d requests", items, requests.Load())
+	}
+}
+
+func TestCatalogs(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "metros.json")
+
+	if err := os.WriteFile(path, []byte(`[{"code":"file0"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	failing := metros.CatalogFunc(func(context.Context) ([]metros.CatalogEntry, error) {
+		return nil, errors.New("unavailable")
+	})
+
+	entries, err := metros.FallbackCatalog(failing, metros.FileCatalog(path)).Metros(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Code != "file0" {
+		t.Errorf("expected the metros of the file, got %+v", entries)
+	}
+
+	var calls int
+	flaky := metros.CatalogFunc(func(ctx context.Context) ([]metros.CatalogEntry, error) {
+		calls++
+		if calls > 1 {
+			return failing(ctx)
+		}
+		return []metros.CatalogEntry{{Code: "once0"}}, nil
+	})
+
+	cached := metros.NewCachedCatalog(flaky, 0)
+	for range 2 {
+		entries, err := cached.Metros(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].Code != "once0" {
+			t.Errorf("expected the expired metros after a failure, got %+v", entries)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the expired cache to be refreshed, got %d calls", calls)
+	}
+
+	if _, err := metros.ParseCatalog(strings.NewReader(`[{"location":"Nowhere"}]`)); err == nil {
+		t.Error("expected an error for an entry without a code")
+	}
+}
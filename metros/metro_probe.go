@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ProbeStage is a step of reaching the API of a metro.
+type ProbeStage string
+
+const (
+	ProbeStageDNS     ProbeStage = "dns"
+	ProbeStageConnect ProbeStage = "connect"
+	ProbeStageTLS     ProbeStage = "tls"
+	ProbeStageAPI     ProbeStage = "api"
+)
+
+// Timings are the times taken by the stages of a probe.  Stages which were
+// not reached are zero.
+type Timings struct {
+	// DNS is the time to resolve the host of the API of the metro.
+	DNS time.Duration `json:"dns"`
+
+	// Connect is the time to dial the tcp connection.
+	Connect time.Duration `json:"connect"`
+
+	// TLS is the time of the TLS handshake.
+	TLS time.Duration `json:"tls"`
+
+	// API is the time until the API responded to an unauthenticated request.
+	API time.Duration `json:"api"`
+}
+
+// ProbeResult is the result of probing a metro.
+type ProbeResult struct {
+	// Code is the code of the metro.
+	Code string `json:"code"`
+
+	// Time is the time at which the probe started.
+	Time time.Time `json:"time"`
+
+	// IP is the resolved address of the API of the metro.
+	IP string `json:"ip,omitempty"`
+
+	// Timings are the times taken by the stages of the probe.
+	Timings Timings `json:"timings"`
+
+	// Stage is the stage at which the probe failed, if any.
+	Stage ProbeStage `json:"stage,omitempty"`
+
+	// Err is the error of the failed stage, if any.
+	Err error `json:"-"`
+}
+
+// Up reports whether the API of the metro was reached.
+func (r ProbeResult) Up() bool {
+	return r.Err == nil
+}
+
+// Probe implements MetrosService.
+func (c *client) Probe(ctx context.Context) ([]ProbeResult, error) {
+	entries, err := c.catalog.Metros(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("discovering metros: %w", err)
+	}
+
+	results := make([]ProbeResult, len(entries))
+
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func(i int, entry CatalogEntry) {
+			defer wg.Done()
+			results[i] = c.probe(ctx, entry)
+		}(i, entry)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// probe resolves the host of the API of the metro, dials it, performs a TLS
+// handshake and finally sends a request to the API over that connection,
+// which is expected to be rejected as unauthorized.  The probe gives up after
+// probeTimeout.
+func (c *client) probe(ctx context.Context, entry CatalogEntry) ProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	result := ProbeResult{
+		Code: entry.Code,
+		Time: time.Now(),
+	}
+
+	fail := func(stage ProbeStage, err error) ProbeResult {
+		result.Stage = stage
+		result.Err = err
+		return result
+	}
+
+	api, err := url.Parse(entry.api())
+	if err != nil {
+		return fail(ProbeStageDNS, fmt.Errorf("parsing API URL: %w", err))
+	}
+
+	port := api.Port()
+	if port == "" {
+		port = "443"
+	}
+
+	// Hosts which are IP addresses need not be resolved.
+	ip := net.ParseIP(api.Hostname())
+	if ip == nil {
+		start := time.Now()
+		ips, err := c.resolver.LookupIP(ctx, "ip4", api.Hostname())
+		result.Timings.DNS = time.Since(start)
+		if err == nil && len(ips) == 0 {
+			err = errors.New("no addresses found")
+		}
+		if err != nil {
+			return fail(ProbeStageDNS, err)
+		}
+
+		ip = ips[0]
+	}
+
+	result.IP = ip.String()
+
+	start := time.Now()
+	conn, err := c.dialer.DialContext(ctx, "tcp", net.JoinHostPort(result.IP, port))
+	result.Timings.Connect = time.Since(start)
+	if err != nil {
+		return fail(ProbeStageConnect, err)
+	}
+	defer conn.Close()
+
+	config := &tls.Config{}
+	if c.tlsConfig != nil {
+		config = c.tlsConfig.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName = api.Hostname()
+	}
+
+	tlsConn := tls.Client(conn, config)
+
+	start = time.Now()
+	err = tlsConn.HandshakeContext(ctx)
+	result.Timings.TLS = time.Since(start)
+	if err != nil {
+		return fail(ProbeStageTLS, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api.JoinPath("/").String(), nil)
+	if err != nil {
+		return fail(ProbeStageAPI, err)
+	}
+
+	// Send the request over the established connection, so that the time of
+	// the API excludes the earlier stages.
+	var used bool
+	transport := &http.Transport{
+		DialTLSContext: func(context.Context, string, string) (net.Conn, error) {
+			if used {
+				return nil, errors.New("connection already used")
+			}
+			used = true
+			return tlsConn, nil
+		},
+		DisableKeepAlives: true,
+	}
+	defer transport.CloseIdleConnections()
+
+	start = time.Now()
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	result.Timings.API = time.Since(start)
+	if err != nil {
+		return fail(ProbeStageAPI, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return fail(ProbeStageAPI, fmt.Errorf("unexpected status %s", resp.Status))
+	}
+
+	return result
+}
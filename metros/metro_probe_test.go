@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/metros"
+)
+
+func TestProbeReusesConnection(t *testing.T) {
+	var conns atomic.Int32
+
+	api := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	api.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	api.StartTLS()
+	t.Cleanup(api.Close)
+
+	client := kraftcloud.NewMetrosClient().
+		WithTLSConfig(tlsConfig(api)).
+		WithCatalog(metros.StaticCatalog(metros.CatalogEntry{Code: "api0", API: "https://api0.example.com"})).
+		WithResolver(fakeResolver{"api0.kraft.host": "192.0.2.2", "api0.example.com": "192.0.2.1"}).
+		WithDialer(fakeDialer{servers: map[string]*httptest.Server{"192.0.2.1": api}})
+
+	results, err := client.Probe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || !results[0].Up() || results[0].Timings.API == 0 {
+		t.Fatalf("expected the metro to be up, got %+v", results)
+	}
+	if results[0].IP != "192.0.2.1" {
+		t.Errorf("expected the host of the API to be dialed, got %s", results[0].IP)
+	}
+	if n := conns.Load(); n != 1 {
+		t.Errorf("expected the API to be requested over the probed connection, got %d connections", n)
+	}
+}
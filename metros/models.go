@@ -14,6 +14,7 @@ import (
 // ListResponseItem is a data item from a response to a /metros/list request.
 // https://docs.kraft.cloud/api/v1/metros/#list-existing-metros
 type ListResponseItem struct {
+	API      string        `json:"api,omitempty"`
 	Code     string        `json:"code"`
 	Delay    time.Duration `json:"delay"`
 	Ipv4     string        `json:"ipv4"`
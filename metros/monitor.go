@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sdk.kraft.cloud/internal/percentile"
+)
+
+const (
+	// DefaultMonitorInterval is the default time between two probes of the
+	// metros by a Monitor.
+	DefaultMonitorInterval = 30 * time.Second
+
+	// DefaultMonitorWindow is the default number of probes per metro from which
+	// the percentiles of a Monitor are computed.
+	DefaultMonitorWindow = 120
+)
+
+// Event reports that a metro went up or down.
+type Event struct {
+	// Code is the code of the metro.
+	Code string `json:"code"`
+
+	// Up is whether the metro is up.
+	Up bool `json:"up"`
+
+	// Result is the probe which observed the transition.
+	Result ProbeResult `json:"result"`
+}
+
+// Percentiles summarize the durations of a stage over the probes in the
+// window.  Probes which failed before the stage are not included.
+type Percentiles struct {
+	Samples int           `json:"samples"`
+	P50     time.Duration `json:"p50"`
+	P90     time.Duration `json:"p90"`
+	P99     time.Duration `json:"p99"`
+	Max     time.Duration `json:"max"`
+}
+
+// MetroStats is the probe history of a metro.
+type MetroStats struct {
+	// Code is the code of the metro.
+	Code string `json:"code"`
+
+	// Up is whether the last probe reached the API of the metro.
+	Up bool `json:"up"`
+
+	// Since is the time of the probe at which the metro last went up or down.
+	Since time.Time `json:"since"`
+
+	// Probes and Failures are the number of probes and failed probes in the
+	// window.
+	Probes   int `json:"probes"`
+	Failures int `json:"failures"`
+
+	// Last is the last probe.
+	Last ProbeResult `json:"last"`
+
+	// Percentiles of the stages of the probes in the window.
+	DNS     Percentiles `json:"dns"`
+	Connect Percentiles `json:"connect"`
+	TLS     Percentiles `json:"tls"`
+	API     Percentiles `json:"api"`
+}
+
+// MonitorOption is an option function used during initialization of a
+// Monitor.
+type MonitorOption func(*Monitor)
+
+// WithMonitorInterval sets the time between two probes of the metros.
+// Defaults to DefaultMonitorInterval if unset or not positive.
+func WithMonitorInterval(interval time.Duration) MonitorOption {
+	return func(m *Monitor) {
+		m.interval = interval
+	}
+}
+
+// WithMonitorWindow sets the number of probes per metro which are kept.
+func WithMonitorWindow(window int) MonitorOption {
+	return func(m *Monitor) {
+		m.window = window
+	}
+}
+
+// WithEventHandler sets the function which is called when a metro goes up or
+// down, including when a metro is probed for the first time.  It is called
+// synchronously and must not block.
+func WithEventHandler(handler func(Event)) MonitorOption {
+	return func(m *Monitor) {
+		m.handler = handler
+	}
+}
+
+// Monitor periodically probes all metros and keeps their history.
+type Monitor struct {
+	metros   MetrosService
+	interval time.Duration
+	window   int
+	handler  func(Event)
+
+	mu      sync.Mutex
+	order   []string
+	history map[string]*history
+}
+
+// history is the rolling window of probes of a metro.
+type history struct {
+	results []ProbeResult
+	next    int
+	up      bool
+	since   time.Time
+}
+
+// NewMonitor instantiates a new Monitor which probes the metros with the given
+// client.
+func NewMonitor(metros MetrosService, mopts ...MonitorOption) *Monitor {
+	m := &Monitor{
+		metros:   metros,
+		interval: DefaultMonitorInterval,
+		window:   DefaultMonitorWindow,
+		history:  make(map[string]*history),
+	}
+
+	for _, opt := range mopts {
+		opt(m)
+	}
+
+	if m.interval <= 0 {
+		m.interval = DefaultMonitorInterval
+	}
+	if m.window < 1 {
+		m.window = 1
+	}
+
+	return m
+}
+
+// Run probes the metros periodically until the context is done.
+func (m *Monitor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		// Failed rounds, e.g. when the metros cannot be discovered, are retried
+		// on the next tick.
+		_ = m.Probe(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Probe probes all metros once and records the results.
+func (m *Monitor) Probe(ctx context.Context) error {
+	results, err := m.metros.Probe(ctx)
+	if err != nil {
+		return err
+	}
+
+	var events []Event
+
+	m.mu.Lock()
+	for _, result := range results {
+		h, ok := m.history[result.Code]
+		if !ok {
+			h = &history{}
+			m.history[result.Code] = h
+			m.order = append(m.order, result.Code)
+		}
+
+		if len(h.results) < m.window {
+			h.results = append(h.results, result)
+		} else {
+			h.results[h.next] = result
+		}
+		h.next = (h.next + 1) % m.window
+
+		if !ok || h.up != result.Up() {
+			h.up = result.Up()
+			h.since = result.Time
+			events = append(events, Event{Code: result.Code, Up: h.up, Result: result})
+		}
+	}
+	m.mu.Unlock()
+
+	if m.handler != nil {
+		for _, event := range events {
+			m.handler(event)
+		}
+	}
+
+	return nil
+}
+
+// Stats returns the probe history of the metro with the given code.
+func (m *Monitor) Stats(code string) (MetroStats, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h, ok := m.history[code]
+	if !ok {
+		return MetroStats{}, false
+	}
+
+	return h.stats(code), true
+}
+
+// AllStats returns the probe history of all metros, in the order in which
+// they were first probed.
+func (m *Monitor) AllStats() []MetroStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]MetroStats, 0, len(m.order))
+	for _, code := range m.order {
+		stats = append(stats, m.history[code].stats(code))
+	}
+
+	return stats
+}
+
+// stats summarizes the history.
+func (h *history) stats(code string) MetroStats {
+	stats := MetroStats{
+		Code:   code,
+		Up:     h.up,
+		Since:  h.since,
+		Probes: len(h.results),
+		Last:   h.results[(h.next+len(h.results)-1)%len(h.results)],
+	}
+
+	var dns, connect, handshake, api []time.Duration
+	for _, result := range h.results {
+		if !result.Up() {
+			stats.Failures++
+		}
+
+		// A stage was completed if the probe failed at a later stage.
+		switch result.Stage {
+		case "":
+			api = append(api, result.Timings.API)
+			fallthrough
+		case ProbeStageAPI:
+			handshake = append(handshake, result.Timings.TLS)
+			fallthrough
+		case ProbeStageTLS:
+			connect = append(connect, result.Timings.Connect)
+			fallthrough
+		case ProbeStageConnect:
+			dns = append(dns, result.Timings.DNS)
+		}
+	}
+
+	stats.DNS = percentiles(dns)
+	stats.Connect = percentiles(connect)
+	stats.TLS = percentiles(handshake)
+	stats.API = percentiles(api)
+
+	return stats
+}
+
+// percentiles computes the nearest-rank percentiles of the durations.
+func percentiles(durations []time.Duration) Percentiles {
+	if len(durations) == 0 {
+		return Percentiles{}
+	}
+
+	sorted := percentile.Sorted(durations)
+
+	return Percentiles{
+		Samples: len(sorted),
+		P50:     percentile.Of(sorted, 0.5),
+		P90:     percentile.Of(sorted, 0.9),
+		P99:     percentile.Of(sorted, 0.99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/metros"
+)
+
+func TestMonitor(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusUnauthorized)
+
+	api := newAPI(t, "api", func() int { return int(status.Load()) })
+
+	var events []metros.Event
+
+	monitor := metros.NewMonitor(
+		kraftcloud.NewMetrosClient().
+			WithHTTPClient(api.Client()).
+			WithTLSConfig(tlsConfig(api)).
+			WithCatalog(metros.StaticCatalog(
+				metros.CatalogEntry{Code: "api0", API: api.URL},
+				metros.CatalogEntry{Code: "gone0"},
+			)).
+			WithResolver(fakeResolver{}).
+			WithDialer(fakeDialer{
+				delays: map[string]time.Duration{api.Listener.Addr().String(): time.Millisecond},
+			}),
+		metros.WithMonitorWindow(3),
+		metros.WithEventHandler(func(event metros.Event) {
+			events = append(events, event)
+		}),
+	)
+
+	ctx := context.Background()
+
+	for _, code := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusServiceUnavailable, http.StatusUnauthorized} {
+		status.Store(int32(code))
+		if err := monitor.Probe(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var transitions []string
+	for _, event := range events {
+		state := "down"
+		if event.Up {
+			state = "up"
+		}
+		transitions = append(transitions, event.Code+" "+state)
+	}
+
+	want := []string{"api0 up", "gone0 down", "api0 down", "api0 up"}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, transitions)
+		}
+	}
+
+	stats, ok := monitor.Stats("api0")
+	if !ok {
+		t.Fatal("expected stats of metro api0")
+	}
+
+	if !stats.Up || stats.Probes != 3 || stats.Failures != 1 {
+		t.Errorf("expected 3 probes in the window with 1 failure, got %+v", stats)
+	}
+	if stats.DNS.Samples != 3 || stats.Connect.Samples != 3 || stats.TLS.Samples != 3 || stats.API.Samples != 2 {
+		t.Errorf("expected the failed API stage to be excluded, got %+v", stats)
+	}
+	if stats.Connect.P50 < time.Millisecond || stats.Connect.Max < stats.Connect.P50 {
+		t.Errorf("expected the connect time to include the dial delay, got %+v", stats.Connect)
+	}
+
+	gone, _ := monitor.Stats("gone0")
+	if gone.Up || gone.Failures != 3 || gone.Last.Stage != metros.ProbeStageDNS || gone.DNS.Samples != 0 {
+		t.Errorf("expected metro gone0 to fail to resolve, got %+v", gone)
+	}
+}
+
+func TestMonitorRunDefaultsInterval(t *testing.T) {
+	// Non-positive intervals fall back to the default instead of panicking.
+	monitor := metros.NewMonitor(
+		kraftcloud.NewMetrosClient().WithCatalog(metros.StaticCatalog()),
+		metros.WithMonitorInterval(0),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := monitor.Run(ctx); err != context.Canceled {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+}
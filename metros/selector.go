@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultHealthRefreshInterval is the default time after which the probe
+// results of a Selector are refreshed.
+const DefaultHealthRefreshInterval = time.Minute
+
+// Health is the health status of a metro.
+type Health struct {
+	// Code is the code of the metro.
+	Code string `json:"code"`
+
+	// API is the base URL of the API of the metro.
+	API string `json:"api"`
+
+	// Online is whether the metro responded to the last probe and no request
+	// failed to reach it since.
+	Online bool `json:"online"`
+
+	// Delay is the time to dial the metro measured by the last probe.
+	Delay time.Duration `json:"delay"`
+
+	// Checked is the time of the last probe.
+	Checked time.Time `json:"checked"`
+
+	// Failures is the number of requests which failed to reach the metro since
+	// the last probe.
+	Failures int `json:"failures"`
+}
+
+// SelectorOption is an option function used during initialization of a
+// Selector.
+type SelectorOption func(*Selector)
+
+// WithHealthRefreshInterval sets the time after which the probe results are
+// refreshed.  Defaults to DefaultHealthRefreshInterval if unset or not
+// positive.
+func WithHealthRefreshInterval(interval time.Duration) SelectorOption {
+	return func(s *Selector) {
+		s.interval = interval
+	}
+}
+
+// WithSelectorClock sets the function returning the current time.
+func WithSelectorClock(now func() time.Time) SelectorOption {
+	return func(s *Selector) {
+		s.now = now
+	}
+}
+
+// Selector probes the metros, caches the results and selects metros by their
+// latency.  To use the fastest metro as the default metro of a client, select
+// it explicitly before creating the client:
+//
+//	selector := metros.NewSelector(kraftcloud.NewMetrosClient())
+//	metro, err := selector.Fastest(ctx)
+//	if err != nil {
+//		// Handle the error or fall back to the default metro.
+//	}
+//	client := kraftcloud.NewClient(kraftcloud.WithDefaultMetro(metro))
+type Selector struct {
+	metros   MetrosService
+	interval time.Duration
+	now      func() time.Time
+
+	// refreshMu serializes probes, while mu guards the results.
+	refreshMu sync.Mutex
+
+	mu      sync.Mutex
+	health  []Health
+	checked time.Time
+}
+
+// NewSelector instantiates a new Selector which probes the metros listed by
+// the given client.  The client must not fail over through the Selector.
+func NewSelector(metros MetrosService, sopts ...SelectorOption) *Selector {
+	s := &Selector{
+		metros:   metros,
+		interval: DefaultHealthRefreshInterval,
+		now:      time.Now,
+	}
+
+	for _, opt := range sopts {
+		opt(s)
+	}
+
+	if s.interval <= 0 {
+		s.interval = DefaultHealthRefreshInterval
+	}
+
+	return s
+}
+
+// Refresh probes all metros and replaces the cached results.
+func (s *Selector) Refresh(ctx context.Context) error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	return s.refresh(ctx)
+}
+
+func (s *Selector) refresh(ctx context.Context) error {
+	items, err := s.metros.List(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+
+	health := make([]Health, len(items))
+	for i, item := range items {
+		health[i] = Health{
+			Code:    item.Code,
+			API:     item.API,
+			Online:  item.Online,
+			Delay:   item.Delay,
+			Checked: now,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.health = health
+	s.checked = now
+
+	return nil
+}
+
+// Health returns the health status of all metros, probing them if the cached
+// results are older than the refresh interval.
+func (s *Selector) Health(ctx context.Context) ([]Health, error) {
+	if s.stale() {
+		s.refreshMu.Lock()
+		var err error
+		if s.stale() {
+			err = s.refresh(ctx)
+		}
+		s.refreshMu.Unlock()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Health(nil), s.health...), nil
+}
+
+// stale reports whether the cached results must be refreshed.
+func (s *Selector) stale() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.health == nil || s.now().Sub(s.checked) >= s.interval
+}
+
+// Ranked returns the health status of the online metros, ordered by their
+// delay.
+func (s *Selector) Ranked(ctx context.Context) ([]Health, error) {
+	health, err := s.Health(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	online := make([]Health, 0, len(health))
+	for _, h := range health {
+		if h.Online {
+			online = append(online, h)
+		}
+	}
+
+	sort.SliceStable(online, func(i, j int) bool {
+		return online[i].Delay < online[j].Delay
+	})
+
+	return online, nil
+}
+
+// Fastest returns the code of the online metro with the lowest delay.
+func (s *Selector) Fastest(ctx context.Context) (string, error) {
+	ranked, err := s.Ranked(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(ranked) == 0 {
+		return "", errors.New("no metro is online")
+	}
+
+	return ranked[0].Code, nil
+}
+
+// ReportFailure marks the metro with the given code as offline until the next
+// probe, e.g. after a request failed to reach it.
+func (s *Selector) ReportFailure(code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.health {
+		if s.health[i].Code == code {
+			s.health[i].Online = false
+			s.health[i].Failures++
+		}
+	}
+}
+
+// Run refreshes the probe results periodically until the context is done.
+func (s *Selector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		// Failed probes are retried on the next tick, the previous results are
+		// kept in the meantime.
+		_ = s.Refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// metroOf returns the code of the metro whose API serves the given URL.
+func (s *Selector) metroOf(u *url.URL) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, h := range s.health {
+		api, err := url.Parse(h.API)
+		if err == nil && api.Host == u.Host {
+			return h.Code
+		}
+	}
+
+	return ""
+}
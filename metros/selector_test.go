@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package metros_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	kraftcloud "sdk.kraft.cloud"
+	"sdk.kraft.cloud/metros"
+)
+
+func TestSelectorFailover(t *testing.T) {
+	slow := newAPI(t, "slow", unauthorized)
+	fast := newAPI(t, "fast", unauthorized)
+	primary := newAPI(t, "primary", unauthorized)
+
+	now := time.Now()
+
+	selector := metros.NewSelector(
+		kraftcloud.NewMetrosClient().
+			WithHTTPClient(slow.Client()).
+			WithTLSConfig(tlsConfig(slow)).
+			WithCatalog(metros.StaticCatalog(
+				metros.CatalogEntry{Code: "slow0", API: slow.URL},
+				metros.CatalogEntry{Code: "fast0", API: fast.URL},
+				metros.CatalogEntry{Code: "primary0", API: primary.URL},
+			)).
+			WithResolver(fakeResolver{}).
+			WithDialer(fakeDialer{
+				delays: map[string]time.Duration{
+					slow.Listener.Addr().String():    20 * time.Millisecond,
+					primary.Listener.Addr().String(): 10 * time.Millisecond,
+				},
+			}),
+		metros.WithSelectorClock(func() time.Time { return now }),
+	)
+
+	ctx := context.Background()
+
+	fastest, err := selector.Fastest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fastest != "fast0" {
+		t.Errorf("expected fast0 to be the fastest metro, got %s", fastest)
+	}
+
+	hc := metros.NewFailoverHTTPClient(slow.Client(), selector)
+
+	do := func(method string) string {
+		req, err := http.NewRequestWithContext(ctx, method, primary.URL+"/v1/users/quotas", strings.NewReader("body"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := hc.Do(req)
+		if err != nil {
+			return err.Error()
+		}
+		defer resp.Body.Close()
+
+		b, _ := io.ReadAll(resp.Body)
+		return string(b)
+	}
+
+	if got := do(http.MethodGet); got != "primary:body" {
+		t.Errorf("expected the primary metro to answer, got %q", got)
+	}
+
+	primary.Close()
+
+	if got := do(http.MethodGet); got != "fast:body" {
+		t.Errorf("expected the request to fail over to the fastest metro, got %q", got)
+	}
+	if got := do(http.MethodPost); strings.HasPrefix(got, "fast") {
+		t.Errorf("expected a mutating request not to fail over, got %q", got)
+	}
+
+	health, err := selector.Health(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range health {
+		if h.Code == "primary0" && (h.Online || h.Failures != 1) {
+			t.Errorf("expected the primary metro to be reported offline, got %+v", h)
+		}
+	}
+
+	// The next probe replaces the reported failures.
+	now = now.Add(metros.DefaultHealthRefreshInterval)
+
+	health, err = selector.Health(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range health {
+		if h.Code == "primary0" && (h.Online || h.Failures != 0 || !h.Checked.Equal(now)) {
+			t.Errorf("expected the primary metro to be probed offline, got %+v", h)
+		}
+	}
+}
+
+func TestSelectorRunDefaultsInterval(t *testing.T) {
+	// Non-positive intervals fall back to the default instead of panicking.
+	selector := metros.NewSelector(
+		kraftcloud.NewMetrosClient().WithCatalog(metros.StaticCatalog()),
+		metros.WithHealthRefreshInterval(0),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := selector.Run(ctx); err != context.Canceled {
+		t.Errorf("expected Run to stop with the context, got %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package multimetro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/metros"
+)
+
+// Result is an item returned by a metro.
+type Result[T any] struct {
+	// Metro is the code of the metro which returned the item.
+	Metro string `json:"metro"`
+
+	// Item is the returned item.
+	Item T `json:"item"`
+}
+
+// MetroError is the error of a request to a single metro.
+type MetroError struct {
+	// Metro is the code of the metro.
+	Metro string
+
+	// Err is the error returned by the metro.
+	Err error
+}
+
+// Error implements error.
+func (e *MetroError) Error() string {
+	return fmt.Sprintf("metro '%s': %s", e.Metro, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *MetroError) Unwrap() error {
+	return e.Err
+}
+
+// Client performs requests across multiple metros.
+type Client struct {
+	client   kraftcloud.KraftCloud
+	metros   []string
+	selector *metros.Selector
+
+	mu     sync.Mutex
+	owners map[owner]string
+}
+
+// ClientOption is an option function used during initialization of a Client.
+type ClientOption func(*Client)
+
+// WithMetros sets the codes of the metros which are queried.  By default, all
+// metros which respond to a probe are queried.
+func WithMetros(metros ...string) ClientOption {
+	return func(c *Client) {
+		c.metros = metros
+	}
+}
+
+// WithSelector sets the Selector whose cached probe results determine the
+// queried metros, instead of probing all metros on every request.  Ignored if
+// the metros are set with WithMetros.
+func WithSelector(selector *metros.Selector) ClientOption {
+	return func(c *Client) {
+		c.selector = selector
+	}
+}
+
+// NewClient instantiates a new Client which performs requests through the
+// given client.
+func NewClient(client kraftcloud.KraftCloud, copts ...ClientOption) *Client {
+	c := &Client{
+		client: client,
+		owners: make(map[owner]string),
+	}
+
+	for _, opt := range copts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Metros returns the codes of the metros which are queried.
+func (c *Client) Metros(ctx context.Context) ([]string, error) {
+	if len(c.metros) > 0 {
+		return c.metros, nil
+	}
+
+	var online []string
+	if c.selector != nil {
+		health, err := c.selector.Health(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("probing metros: %w", err)
+		}
+
+		for _, h := range health {
+			if h.Online {
+				online = append(online, h.Code)
+			}
+		}
+	} else {
+		// Probe the metros, as a metro is otherwise reported online as soon as
+		// its API host resolves.
+		items, err := c.client.Metros().List(ctx, true)
+		if err != nil {
+			return nil, fmt.Errorf("probing metros: %w", err)
+		}
+
+		for _, item := range items {
+			if item.Online {
+				online = append(online, item.Code)
+			}
+		}
+	}
+	if len(online) == 0 {
+		return nil, errors.New("no metro is online")
+	}
+
+	return online, nil
+}
+
+// fanOut calls fn for every metro concurrently and returns the items of all
+// metros, in the order of the metros.  Metros which fail contribute the items
+// they returned nonetheless and a *MetroError.
+func fanOut[T any](ctx context.Context, metros []string, fn func(ctx context.Context, metro string) ([]T, error)) ([]Result[T], error) {
+	items := make([][]T, len(metros))
+	errs := make([]error, len(metros))
+
+	var wg sync.WaitGroup
+	for i, metro := range metros {
+		wg.Add(1)
+		go func(i int, metro string) {
+			defer wg.Done()
+
+			items[i], errs[i] = fn(ctx, metro)
+			if errs[i] != nil {
+				errs[i] = &MetroError{Metro: metro, Err: errs[i]}
+			}
+		}(i, metro)
+	}
+	wg.Wait()
+
+	var results []Result[T]
+	for i, metro := range metros {
+		for _, item := range items[i] {
+			results = append(results, Result[T]{Metro: metro, Item: item})
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// entries returns the entries of a response without an error, together with
+// the combined errors of the response.
+func entries[T kcclient.APIResponseDataEntry](resp *kcclient.ServiceResponse[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := resp.AllOrErr()
+
+	ok := make([]T, 0, len(all))
+	for _, entry := range all {
+		if entry.ErrorAttributes().Error == nil {
+			ok = append(ok, entry)
+		}
+	}
+
+	return ok, err
+}
+
+// owner is the key under which the owner of a resource is cached.  It holds a
+// reference rather than the identifier, so that a name which has the shape of
+// a UUID does not share the key of that UUID.
+type owner struct {
+	kind Kind
+	ref  kcclient.Ref
+}
+
+// remember caches the metro which owns the resource with the given UUID and
+// name.
+func (c *Client) remember(kind Kind, metro, uuid, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if uuid != "" {
+		c.owners[owner{kind, kcclient.ByUUID(uuid)}] = metro
+	}
+	if name != "" {
+		c.owners[owner{kind, kcclient.ByName(name)}] = metro
+	}
+}
+
+// Forget removes the cached owners of the resources with the given
+// identifiers, e.g. after they were deleted.
+func (c *Client) Forget(kind Kind, ids ...kcclient.Ref) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, id := range ids {
+		delete(c.owners, owner{kind, id})
+	}
+}
+
+// cachedOwner returns the cached metro which owns the resource.
+func (c *Client) cachedOwner(kind Kind, id kcclient.Ref) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	metro, ok := c.owners[owner{kind, id}]
+	return metro, ok
+}
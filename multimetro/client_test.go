@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package multimetro_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/metros"
+	"sdk.kraft.cloud/multimetro"
+)
+
+// newMetro starts a fake metro which owns a single instance.
+func newMetro(t *testing.T, uuid, name string, gets *atomic.Int32) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		instance := fmt.Sprintf(`{"status":"success","uuid":%q,"name":%q,"state":"running"}`, uuid, name)
+
+		if r.URL.Path == "/instances" && r.Body != nil && r.ContentLength != 0 {
+			gets.Add(1)
+		}
+
+		var reqItems []map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&reqItems)
+
+		switch r.URL.Path {
+		case "/instances", "/instances/metrics", "/instances/stop":
+			if len(reqItems) == 0 {
+				fmt.Fprintf(w, `{"status":"success","data":{"instances":[%s]}}`, instance)
+				return
+			}
+
+			status := "success"
+			entries := make([]string, 0, len(reqItems))
+			for _, item := range reqItems {
+				if item["uuid"] == uuid || item["name"] == name {
+					entries = append(entries, instance)
+				} else {
+					status = "partial_success"
+					entries = append(entries, `{"status":"error","message":"not found","error":8}`)
+				}
+			}
+			fmt.Fprintf(w, `{"status":%q,"data":{"instances":[%s]}}`, status, strings.Join(entries, ","))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestClient(t *testing.T) {
+	var gets atomic.Int32
+
+	a := newMetro(t, "00000000-0000-0000-0000-00000000000a", "a", &gets)
+	b := newMetro(t, "00000000-0000-0000-0000-00000000000b", "b", &gets)
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(down.Close)
+
+	ctx := context.Background()
+
+	client := multimetro.NewClient(kraftcloud.NewClient(), multimetro.WithMetros(a.URL, b.URL, down.URL))
+
+	instances, err := client.ListInstances(ctx)
+
+	var metroErr *multimetro.MetroError
+	if !errors.As(err, &metroErr) || metroErr.Metro != down.URL {
+		t.Errorf("expected an error of the unavailable metro, got %v", err)
+	}
+	if len(instances) != 2 ||
+		instances[0].Metro != a.URL || instances[0].Item.Name != "a" ||
+		instances[1].Metro != b.URL || instances[1].Item.Name != "b" {
+		t.Fatalf("expected instances of both available metros, got %+v", instances)
+	}
+
+	// Owners are known from the listing, so no lookup is needed.
+	got, err := client.GetInstances(ctx, kcclient.ByName("b"), kcclient.ByName("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Item.Name != "b" || got[0].Metro != b.URL || got[1].Item.Name != "a" {
+		t.Fatalf("expected instances in request order, got %+v", got)
+	}
+	if n := gets.Load(); n != 2 {
+		t.Errorf("expected only one request per owning metro, got %d", n)
+	}
+
+	// Unknown owners are looked up in all metros.
+	client = multimetro.NewClient(kraftcloud.NewClient(), multimetro.WithMetros(a.URL, b.URL))
+
+	metrics, err := client.Metrics(ctx, kcclient.ByName("b"), kcclient.ByName("missing"))
+	if err == nil || !strings.Contains(err.Error(), "instance 'missing' not found in any metro") {
+		t.Errorf("expected an error for the missing instance, got %v", err)
+	}
+	if len(metrics) != 1 || metrics[0].Item.Name != "b" || metrics[0].Metro != b.URL {
+		t.Fatalf("expected metrics of instance 'b', got %+v", metrics)
+	}
+
+	scoped, err := client.Instances(ctx, kcclient.ByName("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := scoped.Stop(ctx, 0, false, kcclient.ByName("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item, err := resp.FirstOrErr(); err != nil || item.Name != "a" {
+		t.Fatalf("expected instance 'a' to be stopped in its metro, got %+v (%v)", item, err)
+	}
+}
+
+func TestClientNameShapedLikeUUID(t *testing.T) {
+	var gets atomic.Int32
+
+	const uuid = "00000000-0000-0000-0000-00000000000a"
+
+	a := newMetro(t, uuid, "a", &gets)
+	b := newMetro(t, "00000000-0000-0000-0000-00000000000b", uuid, &gets)
+
+	ctx := context.Background()
+
+	client := multimetro.NewClient(kraftcloud.NewClient(), multimetro.WithMetros(a.URL, b.URL))
+	if _, err := client.ListInstances(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.GetInstances(ctx, kcclient.ByUUID(uuid), kcclient.ByName(uuid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Metro != a.URL || got[0].Item.Name != "a" || got[1].Metro != b.URL || got[1].Item.Name != uuid {
+		t.Fatalf("expected the UUID and the name to be routed to their own metros, got %+v", got)
+	}
+}
+
+// probedMetros lists metros whose API host resolves, of which only some
+// respond to probes.
+type probedMetros struct {
+	metros.MetrosService
+	status []bool
+}
+
+func (m *probedMetros) List(_ context.Context, status bool) ([]metros.ListResponseItem, error) {
+	m.status = append(m.status, status)
+
+	return []metros.ListResponseItem{
+		{Code: "a", Ipv4: "192.0.2.1", Online: true},
+		{Code: "b", Ipv4: "192.0.2.2", Online: !status},
+	}, nil
+}
+
+func TestClientSelector(t *testing.T) {
+	fake := &probedMetros{}
+	client := multimetro.NewClient(kraftcloud.NewClient(),
+		multimetro.WithSelector(metros.NewSelector(fake)),
+	)
+
+	ctx := context.Background()
+
+	for range 2 {
+		codes, err := client.Metros(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(codes) != 1 || codes[0] != "a" {
+			t.Fatalf("expected only the probed metro, got %v", codes)
+		}
+	}
+
+	if len(fake.status) != 1 || !fake.status[0] {
+		t.Errorf("expected a single probing list, got %v", fake.status)
+	}
+}
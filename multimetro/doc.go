@@ -0,0 +1,9 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package multimetro queries resources across multiple KraftCloud metros at
+// once and routes operations on individual resources to the metro which owns
+// them.
+package multimetro
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package multimetro
+
+import (
+	"context"
+
+	"sdk.kraft.cloud/certificates"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/services"
+	"sdk.kraft.cloud/users"
+	"sdk.kraft.cloud/volumes"
+)
+
+// list fans out a list request to all metros and caches the owners of the
+// returned resources.
+func list[T kcclient.Identified](ctx context.Context, c *Client, kind Kind, fn func(metro string) func(context.Context) (*kcclient.ServiceResponse[T], error)) ([]Result[T], error) {
+	metros, err := c.Metros(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := fanOut(ctx, metros, func(ctx context.Context, metro string) ([]T, error) {
+		return entries(fn(metro)(ctx))
+	})
+
+	for i := range results {
+		identity := results[i].Item.Identity()
+		c.remember(kind, results[i].Metro, identity.UUID, identity.Name)
+	}
+
+	return results, err
+}
+
+// ListInstances returns the instances of all metros.  Metros which fail are
+// reported in the returned error alongside the instances of the other metros.
+func (c *Client) ListInstances(ctx context.Context) ([]Result[instances.GetResponseItem], error) {
+	return list(ctx, c, KindInstance,
+		func(metro string) func(context.Context) (*kcclient.ServiceResponse[instances.GetResponseItem], error) {
+			return c.client.Instances().WithMetro(metro).List
+		},
+	)
+}
+
+// ListServices returns the service groups of all metros.
+func (c *Client) ListServices(ctx context.Context) ([]Result[services.GetResponseItem], error) {
+	return list(ctx, c, KindService,
+		func(metro string) func(context.Context) (*kcclient.ServiceResponse[services.GetResponseItem], error) {
+			return c.client.Services().WithMetro(metro).List
+		},
+	)
+}
+
+// ListVolumes returns the volumes of all metros.
+func (c *Client) ListVolumes(ctx context.Context) ([]Result[volumes.GetResponseItem], error) {
+	return list(ctx, c, KindVolume,
+		func(metro string) func(context.Context) (*kcclient.ServiceResponse[volumes.GetResponseItem], error) {
+			return c.client.Volumes().WithMetro(metro).List
+		},
+	)
+}
+
+// ListCertificates returns the certificates of all metros.
+func (c *Client) ListCertificates(ctx context.Context) ([]Result[certificates.GetResponseItem], error) {
+	return list(ctx, c, KindCertificate,
+		func(metro string) func(context.Context) (*kcclient.ServiceResponse[certificates.GetResponseItem], error) {
+			return c.client.Certificates().WithMetro(metro).List
+		},
+	)
+}
+
+// GetInstances returns the instances with the given identifiers, in the same
+// order, from the metros which own them.  Instances which cannot be found are
+// reported in the returned error.
+func (c *Client) GetInstances(ctx context.Context, ids ...kcclient.Ref) ([]Result[instances.GetResponseItem], error) {
+	return routed(ctx, c, KindInstance, ids, func(metro string) kcclient.BatchFunc[instances.GetResponseItem] {
+		return c.client.Instances().WithMetro(metro).Get
+	})
+}
+
+// GetServices returns the service groups with the given identifiers from the
+// metros which own them.
+func (c *Client) GetServices(ctx context.Context, ids ...kcclient.Ref) ([]Result[services.GetResponseItem], error) {
+	return routed(ctx, c, KindService, ids, func(metro string) kcclient.BatchFunc[services.GetResponseItem] {
+		return c.client.Services().WithMetro(metro).Get
+	})
+}
+
+// GetVolumes returns the volumes with the given identifiers from the metros
+// which own them.
+func (c *Client) GetVolumes(ctx context.Context, ids ...kcclient.Ref) ([]Result[volumes.GetResponseItem], error) {
+	return routed(ctx, c, KindVolume, ids, func(metro string) kcclient.BatchFunc[volumes.GetResponseItem] {
+		return c.client.Volumes().WithMetro(metro).Get
+	})
+}
+
+// GetCertificates returns the certificates with the given identifiers from
+// the metros which own them.
+func (c *Client) GetCertificates(ctx context.Context, ids ...kcclient.Ref) ([]Result[certificates.GetResponseItem], error) {
+	return routed(ctx, c, KindCertificate, ids, func(metro string) kcclient.BatchFunc[certificates.GetResponseItem] {
+		return c.client.Certificates().WithMetro(metro).Get
+	})
+}
+
+// Metrics returns the metrics of the instances with the given identifiers, in
+// the same order, from the metros which own them.
+func (c *Client) Metrics(ctx context.Context, ids ...kcclient.Ref) ([]Result[instances.MetricsResponseItem], error) {
+	return routed(ctx, c, KindInstance, ids, func(metro string) kcclient.BatchFunc[instances.MetricsResponseItem] {
+		return c.client.Instances().WithMetro(metro).Metrics
+	})
+}
+
+// Quotas returns the quotas of the user in all metros.
+func (c *Client) Quotas(ctx context.Context) ([]Result[users.QuotasResponseItem], error) {
+	metros, err := c.Metros(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return fanOut(ctx, metros, func(ctx context.Context, metro string) ([]users.QuotasResponseItem, error) {
+		return entries(c.client.Users().WithMetro(metro).Quotas(ctx))
+	})
+}
+
+// Instances returns an instances client scoped to the metro which owns the
+// instance with the given identifier, e.g. to stop or delete it.
+func (c *Client) Instances(ctx context.Context, id kcclient.Ref) (instances.InstancesService, error) {
+	metro, err := c.owner(ctx, KindInstance, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Instances().WithMetro(metro), nil
+}
+
+// Services returns a service groups client scoped to the metro which owns the
+// service group with the given identifier.
+func (c *Client) Services(ctx context.Context, id kcclient.Ref) (services.ServicesService, error) {
+	metro, err := c.owner(ctx, KindService, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Services().WithMetro(metro), nil
+}
+
+// Volumes returns a volumes client scoped to the metro which owns the volume
+// with the given identifier.
+func (c *Client) Volumes(ctx context.Context, id kcclient.Ref) (volumes.VolumesService, error) {
+	metro, err := c.owner(ctx, KindVolume, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Volumes().WithMetro(metro), nil
+}
+
+// Certificates returns a certificates client scoped to the metro which owns
+// the certificate with the given identifier.
+func (c *Client) Certificates(ctx context.Context, id kcclient.Ref) (certificates.CertificatesService, error) {
+	metro, err := c.owner(ctx, KindCertificate, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.client.Certificates().WithMetro(metro), nil
+}
+
+// owner returns the metro which owns the resource with the given identifier.
+func (c *Client) owner(ctx context.Context, kind Kind, id kcclient.Ref) (string, error) {
+	owners, err := c.Locate(ctx, kind, id)
+	if err != nil {
+		return "", err
+	}
+
+	return owners[0], nil
+}
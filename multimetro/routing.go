@@ -0,0 +1,270 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package multimetro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"sdk.kraft.cloud/certificates"
+	kcclient "sdk.kraft.cloud/client"
+	kcerrors "sdk.kraft.cloud/client/errors"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/services"
+	"sdk.kraft.cloud/volumes"
+)
+
+// Kind is the kind of a resource which is owned by a metro.
+type Kind string
+
+const (
+	KindInstance    Kind = "instance"
+	KindService     Kind = "service group"
+	KindVolume      Kind = "volume"
+	KindCertificate Kind = "certificate"
+)
+
+// finder reports for every identifier the identity of the resource found in
+// the metro, or nil if the metro has no such resource.
+type finder func(ctx context.Context, metro string, ids ...kcclient.Ref) ([]*kcclient.Identity, error)
+
+// find returns a finder which looks up resources with the given method.
+func find[T kcclient.Identified](get func(metro string) kcclient.BatchFunc[T]) finder {
+	return func(ctx context.Context, metro string, ids ...kcclient.Ref) ([]*kcclient.Identity, error) {
+		found := make([]*kcclient.Identity, len(ids))
+
+		resp, err := get(metro)(ctx, ids...)
+		if err != nil {
+			// A metro which has none of the resources may reject the request
+			// altogether.
+			var apiErr *kcerrors.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				return found, nil
+			}
+			return nil, err
+		}
+
+		if len(resp.Data.Entries) != len(ids) {
+			return nil, fmt.Errorf("expected %d entries in response, got %d", len(ids), len(resp.Data.Entries))
+		}
+
+		for i := range resp.Data.Entries {
+			if resp.Data.Entries[i].ErrorAttributes().Error == nil {
+				identity := resp.Data.Entries[i].Identity()
+				found[i] = &identity
+			}
+		}
+
+		return found, nil
+	}
+}
+
+// finder returns the finder of resources of the given kind.
+func (c *Client) finder(kind Kind) (finder, error) {
+	switch kind {
+	case KindInstance:
+		return find(
+			func(metro string) kcclient.BatchFunc[instances.GetResponseItem] {
+				return c.client.Instances().WithMetro(metro).Get
+			},
+		), nil
+	case KindService:
+		return find(
+			func(metro string) kcclient.BatchFunc[services.GetResponseItem] {
+				return c.client.Services().WithMetro(metro).Get
+			},
+		), nil
+	case KindVolume:
+		return find(
+			func(metro string) kcclient.BatchFunc[volumes.GetResponseItem] {
+				return c.client.Volumes().WithMetro(metro).Get
+			},
+		), nil
+	case KindCertificate:
+		return find(
+			func(metro string) kcclient.BatchFunc[certificates.GetResponseItem] {
+				return c.client.Certificates().WithMetro(metro).Get
+			},
+		), nil
+	default:
+		return nil, fmt.Errorf("unknown resource kind '%s'", kind)
+	}
+}
+
+// Locate returns the codes of the metros which own the resources of the given
+// kind with the given identifiers, in the same order.  Owners are cached, and
+// resources with unknown owners are looked up in all metros at once.  A name
+// which exists in several metros is an error.  The owners of resources which
+// could not be located are empty.
+func (c *Client) Locate(ctx context.Context, kind Kind, ids ...kcclient.Ref) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("requires at least one identifier")
+	}
+
+	owners := make([]string, len(ids))
+
+	var unknown []int
+	for i, id := range ids {
+		if metro, ok := c.cachedOwner(kind, id); ok {
+			owners[i] = metro
+		} else {
+			unknown = append(unknown, i)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return owners, nil
+	}
+
+	find, err := c.finder(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	metros, err := c.Metros(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := make([]kcclient.Ref, len(unknown))
+	for n, i := range unknown {
+		lookup[n] = ids[i]
+	}
+
+	found := make([][]*kcclient.Identity, len(metros))
+	metroErrs := make([]error, len(metros))
+
+	var wg sync.WaitGroup
+	for m, metro := range metros {
+		wg.Add(1)
+		go func(m int, metro string) {
+			defer wg.Done()
+
+			found[m], metroErrs[m] = find(ctx, metro, lookup...)
+			if metroErrs[m] != nil {
+				metroErrs[m] = &MetroError{Metro: metro, Err: metroErrs[m]}
+			}
+		}(m, metro)
+	}
+	wg.Wait()
+
+	var errs []error
+	for n, i := range unknown {
+		var candidates []string
+		for m, metro := range metros {
+			if found[m] == nil || found[m][n] == nil {
+				continue
+			}
+			candidates = append(candidates, metro)
+			c.remember(kind, metro, found[m][n].UUID, found[m][n].Name)
+		}
+
+		switch len(candidates) {
+		case 0:
+			errs = append(errs, fmt.Errorf("%s '%s' not found in any metro", kind, ids[i]))
+		case 1:
+			owners[i] = candidates[0]
+		default:
+			c.Forget(kind, ids[i])
+			errs = append(errs, fmt.Errorf("%s '%s' exists in multiple metros: %s", kind, ids[i], strings.Join(candidates, ", ")))
+		}
+	}
+
+	// Failed metros may own the resources which were not found.
+	if len(errs) > 0 {
+		errs = append(errs, metroErrs...)
+	}
+
+	return owners, errors.Join(errs...)
+}
+
+// route groups the indices of the identifiers by the metro which owns them.
+// Identifiers without an owner are omitted.
+func route(ids []kcclient.Ref, owners []string) map[string][]int {
+	routes := make(map[string][]int)
+	for i := range ids {
+		if owners[i] != "" {
+			routes[owners[i]] = append(routes[owners[i]], i)
+		}
+	}
+
+	return routes
+}
+
+// routed calls fn once per owning metro with the identifiers it owns and
+// returns the entries in the order of the identifiers.  Entries with an error
+// are omitted, and their errors are combined.
+func routed[T kcclient.APIResponseDataEntry](ctx context.Context, c *Client, kind Kind, ids []kcclient.Ref, fn func(metro string) kcclient.BatchFunc[T]) ([]Result[T], error) {
+	owners, locateErr := c.Locate(ctx, kind, ids...)
+	if owners == nil {
+		return nil, locateErr
+	}
+
+	routes := route(ids, owners)
+	entries := make([]*Result[T], len(ids))
+
+	var (
+		mu   sync.Mutex
+		errs = []error{locateErr}
+		wg   sync.WaitGroup
+	)
+
+	for metro, indices := range routes {
+		wg.Add(1)
+		go func(metro string, indices []int) {
+			defer wg.Done()
+
+			batch := make([]kcclient.Ref, len(indices))
+			for n, i := range indices {
+				batch[n] = ids[i]
+			}
+
+			resp, err := fn(metro)(ctx, batch...)
+			if err == nil && len(resp.Data.Entries) != len(batch) {
+				err = fmt.Errorf("expected %d entries in response, got %d", len(batch), len(resp.Data.Entries))
+			}
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, &MetroError{Metro: metro, Err: err})
+				mu.Unlock()
+				return
+			}
+
+			for n, i := range indices {
+				entry := resp.Data.Entries[n]
+				if attrs := entry.ErrorAttributes(); attrs.Error != nil {
+					// The resource may have moved or been deleted since its owner
+					// was cached.
+					c.Forget(kind, ids[i])
+
+					mu.Lock()
+					errs = append(errs, &MetroError{
+						Metro: metro,
+						Err:   fmt.Errorf("%s '%s': %s (code=%d)", kind, ids[i], attrs.Message, *attrs.Error),
+					})
+					mu.Unlock()
+					continue
+				}
+
+				entries[i] = &Result[T]{Metro: metro, Item: entry}
+			}
+		}(metro, indices)
+	}
+	wg.Wait()
+
+	results := make([]Result[T], 0, len(ids))
+	for _, entry := range entries {
+		if entry != nil {
+			results = append(results, *entry)
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
@@ -14,22 +14,17 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // DeleteConfigurations implements AutoscaleService.
-func (c *client) DeleteConfigurations(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[DeleteResponseItem], error) {
+func (c *client) DeleteConfigurations(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[DeleteResponseItem], error) {
 	if len(ids) == 0 {
 		return nil, errors.New("requires at least one identifier")
 	}
 
 	reqItems := make([]map[string]string, 0, len(ids))
 	for _, id := range ids {
-		if uuid.IsValid(id) {
-			reqItems = append(reqItems, map[string]string{"uuid": id})
-		} else {
-			reqItems = append(reqItems, map[string]string{"name": id})
-		}
+		reqItems = append(reqItems, map[string]string{id.Attr(): id.Value()})
 	}
 
 	body, err := json.Marshal(reqItems)
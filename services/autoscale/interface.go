@@ -19,10 +19,10 @@ type AutoscaleService interface {
 
 	// GetConfigurations returns the current states and configurations of
 	// autoscale configurations.
-	GetConfigurations(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[GetResponseItem], error)
+	GetConfigurations(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[GetResponseItem], error)
 
 	// DeleteConfigurations deletes autoscale configurations.
-	DeleteConfigurations(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[DeleteResponseItem], error)
+	DeleteConfigurations(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[DeleteResponseItem], error)
 
 	// AddPolicy adds a new autoscale policy to an autoscale configuration.
 	AddPolicy(ctx context.Context, autoscaleUUID string, req Policy) (*kcclient.ServiceResponse[AddPolicyResponseItem], error)
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package replay
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Difference is a point in time at which two replays predict a different
+// number of replicas.
+type Difference struct {
+	Time   time.Time `json:"time"`
+	Before int       `json:"before"`
+	After  int       `json:"after"`
+}
+
+// Comparison sets two replays of the same trace side by side, e.g. of the
+// current configuration and of a proposed policy change.
+type Comparison struct {
+	Before *Result `json:"before"`
+	After  *Result `json:"after"`
+
+	// Differences are the snapshots at which the number of replicas differs,
+	// ordered by time.
+	Differences []Difference `json:"differences"`
+}
+
+// Compare sets the two replays side by side.  Their points are matched by
+// time, so both should be replays of the same trace.
+func Compare(before, after *Result) *Comparison {
+	c := &Comparison{
+		Before: before,
+		After:  after,
+	}
+
+	// Points are keyed by instant, since equal times may differ in location or
+	// monotonic clock reading, e.g. after a round trip through JSON.
+	replicas := make(map[int64]int, len(after.Simulation.Points))
+	for _, point := range after.Simulation.Points {
+		replicas[point.Time.UnixNano()] = point.Replicas
+	}
+
+	for _, point := range before.Simulation.Points {
+		n, ok := replicas[point.Time.UnixNano()]
+		if ok && n != point.Replicas {
+			c.Differences = append(c.Differences, Difference{
+				Time:   point.Time,
+				Before: point.Replicas,
+				After:  n,
+			})
+		}
+	}
+
+	return c
+}
+
+// WriteMarkdown renders the comparison as Markdown tables, e.g. for a review
+// of the policy change.
+func (c *Comparison) WriteMarkdown(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "## Autoscale replay\n\nReplayed from %s to %s.\n\n",
+		c.Before.Start.Format(time.RFC3339), c.Before.End.Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	b, a := c.Before, c.After
+	rows := []struct {
+		name          string
+		before, after string
+		change        string
+	}{
+		{"Size limits", fmt.Sprintf("%d-%d", b.MinSize, b.MaxSize), fmt.Sprintf("%d-%d", a.MinSize, a.MaxSize), ""},
+		{"Scale events", fmt.Sprint(len(b.Simulation.Events)), fmt.Sprint(len(a.Simulation.Events)), fmt.Sprintf("%+d", len(a.Simulation.Events)-len(b.Simulation.Events))},
+		{"Scale outs", fmt.Sprint(b.ScaleOuts), fmt.Sprint(a.ScaleOuts), fmt.Sprintf("%+d", a.ScaleOuts-b.ScaleOuts)},
+		{"Scale ins", fmt.Sprint(b.ScaleIns), fmt.Sprint(a.ScaleIns), fmt.Sprintf("%+d", a.ScaleIns-b.ScaleIns)},
+		{"Peak replicas", fmt.Sprint(b.PeakReplicas), fmt.Sprint(a.PeakReplicas), fmt.Sprintf("%+d", a.PeakReplicas-b.PeakReplicas)},
+		{"Time at max size", b.TimeAtMax.String(), a.TimeAtMax.String(), formatDelta(a.TimeAtMax - b.TimeAtMax)},
+		{"Instance-hours", fmt.Sprintf("%.2f", b.InstanceHours), fmt.Sprintf("%.2f", a.InstanceHours), fmt.Sprintf("%+.2f", a.InstanceHours-b.InstanceHours)},
+	}
+
+	if _, err := fmt.Fprint(w, "| | Before | After | Change |\n|---|---:|---:|---:|\n"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, "| %s | %s | %s | %s |\n", row.name, row.before, row.after, row.change); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Differences) == 0 {
+		_, err := fmt.Fprint(w, "\nBoth configurations predict the same number of replicas throughout.\n")
+		return err
+	}
+
+	if _, err := fmt.Fprint(w, "\n### Replicas\n\n| Time | Before | After |\n|---|---:|---:|\n"); err != nil {
+		return err
+	}
+	for _, d := range c.Differences {
+		if _, err := fmt.Fprintf(w, "| %s | %d | %d |\n", d.Time.Format(time.RFC3339), d.Before, d.After); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// formatDelta formats a change of duration with an explicit sign.
+func formatDelta(d time.Duration) string {
+	if d >= 0 {
+		return "+" + d.String()
+	}
+
+	return d.String()
+}
@@ -0,0 +1,10 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+// Package replay replays recorded instance metrics through the step policies
+// of an autoscale configuration, so that the scale events, time spent at the
+// maximum size and instance-hours of a policy change can be compared before
+// it is applied.
+package replay
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package replay
+
+// ReplayerOption is an option function used during initialization of a
+// Replayer.
+type ReplayerOption func(*Replayer)
+
+// WithInitialSize sets the number of replicas at the start of the trace.  By
+// default, it is the number of running instances in the first snapshot,
+// clamped to the size limits of the replayed configuration.
+func WithInitialSize(n int) ReplayerOption {
+	return func(r *Replayer) {
+		r.initialSize = n
+	}
+}
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/services/autoscale"
+)
+
+// Result is the outcome of replaying a trace through an autoscale
+// configuration.
+type Result struct {
+	// Name of the service group of the configuration.
+	Name string `json:"name"`
+
+	// Start and End are the times of the first and last snapshot.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// MinSize and MaxSize are the size limits of the configuration.
+	MinSize int `json:"min_size"`
+	MaxSize int `json:"max_size"`
+
+	// Simulation is the predicted number of replicas at each snapshot.
+	Simulation *autoscale.Simulation `json:"simulation"`
+
+	// ScaleOuts and ScaleIns count the scale events by direction.
+	ScaleOuts int `json:"scale_outs"`
+	ScaleIns  int `json:"scale_ins"`
+
+	// PeakReplicas is the highest number of replicas.
+	PeakReplicas int `json:"peak_replicas"`
+
+	// TimeAtMax is the time spent at the maximum size.
+	TimeAtMax time.Duration `json:"time_at_max"`
+
+	// InstanceHours is the estimated number of instance-hours, including
+	// replicas which are still warming up.
+	InstanceHours float64 `json:"instance_hours"`
+}
+
+// Replayer replays a recorded trace through autoscale configurations.
+type Replayer struct {
+	trace        Trace
+	observations []autoscale.Observation
+	initialSize  int
+}
+
+// NewReplayer instantiates a new Replayer of the given trace, whose snapshots
+// are sorted by time first.
+func NewReplayer(trace Trace, ropts ...ReplayerOption) *Replayer {
+	trace = slices.Clone(trace)
+	slices.SortStableFunc(trace, func(a, b Snapshot) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	r := &Replayer{
+		trace:        trace,
+		observations: trace.Observations(),
+	}
+
+	for _, opt := range ropts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Replay replays the trace through the step policies of the configuration.
+// On-demand policies are not simulated and are ignored.
+//
+// The snapshots hold the total load of the recorded service group, which is
+// divided among the ready replicas of the simulated one.  The number of
+// replicas after each snapshot is assumed to hold until the next one.
+func (r *Replayer) Replay(config *autoscale.GetResponseItem) (*Result, error) {
+	if len(r.trace) == 0 {
+		return nil, errors.New("requires at least one snapshot")
+	}
+	if config.MinSize == nil || config.MaxSize == nil {
+		return nil, fmt.Errorf("autoscale configuration of '%s' has no size limits", config.Name)
+	}
+
+	var policies []autoscale.StepPolicy
+	for _, policy := range config.Policies {
+		switch p := policy.(type) {
+		case autoscale.StepPolicy:
+			policies = append(policies, p)
+		case *autoscale.StepPolicy:
+			policies = append(policies, *p)
+		}
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("autoscale configuration of '%s' has no step policies", config.Name)
+	}
+
+	simConfig := autoscale.SimulationConfig{
+		MinSize:     *config.MinSize,
+		MaxSize:     *config.MaxSize,
+		InitialSize: r.initialSize,
+		Total:       true,
+	}
+	if config.WarmupTimeMs != nil {
+		simConfig.Warmup = time.Duration(*config.WarmupTimeMs) * time.Millisecond
+	}
+	if config.CooldownTimeMs != nil {
+		simConfig.Cooldown = time.Duration(*config.CooldownTimeMs) * time.Millisecond
+	}
+	if simConfig.InitialSize == 0 {
+		running := 0
+		for _, item := range r.trace[0].Instances {
+			if item.State == instances.InstanceStateRunning {
+				running++
+			}
+		}
+
+		simConfig.InitialSize = min(max(running, simConfig.MinSize), simConfig.MaxSize)
+	}
+
+	sim, err := autoscale.SimulatePolicies(policies, r.observations, simConfig)
+	if err != nil {
+		return nil, fmt.Errorf("simulating '%s': %w", config.Name, err)
+	}
+
+	result := &Result{
+		Name:       config.Name,
+		Start:      r.trace[0].Time,
+		End:        r.trace[len(r.trace)-1].Time,
+		MinSize:    simConfig.MinSize,
+		MaxSize:    simConfig.MaxSize,
+		Simulation: sim,
+	}
+
+	for _, event := range sim.Events {
+		if event.To > event.From {
+			result.ScaleOuts++
+		} else {
+			result.ScaleIns++
+		}
+	}
+
+	for i, point := range sim.Points {
+		result.PeakReplicas = max(result.PeakReplicas, point.Replicas)
+
+		if i == len(sim.Points)-1 {
+			break
+		}
+
+		d := sim.Points[i+1].Time.Sub(point.Time)
+		if point.Replicas == result.MaxSize {
+			result.TimeAtMax += d
+		}
+		result.InstanceHours += float64(point.Replicas) * d.Hours()
+	}
+
+	return result, nil
+}
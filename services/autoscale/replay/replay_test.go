@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package replay_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/services/autoscale"
+	"sdk.kraft.cloud/services/autoscale/replay"
+)
+
+const uuid1 = "00000000-0000-0000-0000-000000000001"
+
+func bound(n int) *int {
+	return &n
+}
+
+var start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// trace returns one snapshot per minute of a single instance with the given
+// numbers of in-flight requests.
+func trace(requests ...uint64) replay.Trace {
+	var t replay.Trace
+	for i, n := range requests {
+		t = append(t, replay.Snapshot{
+			Time: start.Add(time.Duration(i) * time.Minute),
+			Instances: []instances.MetricsResponseItem{{
+				UUID:     uuid1,
+				State:    instances.InstanceStateRunning,
+				Requests: n,
+			}},
+		})
+	}
+
+	return t
+}
+
+// config returns an autoscale configuration with a single step policy which
+// scales on in-flight requests.
+func config(maxSize, threshold, adjustment int) *autoscale.GetResponseItem {
+	return &autoscale.GetResponseItem{
+		Name:           "web",
+		Enabled:        true,
+		MinSize:        bound(1),
+		MaxSize:        bound(maxSize),
+		WarmupTimeMs:   bound(0),
+		CooldownTimeMs: bound(60000),
+		Policies: []autoscale.Policy{
+			autoscale.StepPolicy{
+				Name:           "reqs",
+				Metric:         autoscale.PolicyMetricInflightRequests,
+				AdjustmentType: autoscale.AdjustmentTypeChange,
+				Steps: []autoscale.Step{
+					{Adjustment: -1, UpperBound: bound(10)},
+					{Adjustment: 0, LowerBound: bound(10), UpperBound: bound(threshold)},
+					{Adjustment: adjustment, LowerBound: bound(threshold)},
+				},
+			},
+			autoscale.OnDemandPolicy{Name: "ondemand"},
+		},
+	}
+}
+
+func TestReplay(t *testing.T) {
+	// Snapshots are sorted before they are replayed.
+	tr := trace(5, 40, 40, 40, 40, 40, 5, 5)
+	tr[0], tr[7] = tr[7], tr[0]
+
+	r := replay.NewReplayer(tr)
+
+	before, err := r.Replay(config(2, 30, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := r.Replay(config(4, 20, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if before.ScaleOuts != 1 || before.ScaleIns != 1 || before.PeakReplicas != 2 {
+		t.Errorf("expected one scale out to 2 replicas and one scale in, got %+v", before.Simulation.Events)
+	}
+	if before.TimeAtMax != 5*time.Minute || after.TimeAtMax != 0 {
+		t.Errorf("expected 5m and 0s at max size, got %s and %s", before.TimeAtMax, after.TimeAtMax)
+	}
+	if math.Abs(before.InstanceHours-0.2) > 1e-9 || math.Abs(after.InstanceHours-0.3) > 1e-9 {
+		t.Errorf("expected 0.2 and 0.3 instance-hours, got %f and %f", before.InstanceHours, after.InstanceHours)
+	}
+
+	c := replay.Compare(before, after)
+	if len(c.Differences) != 6 || c.Differences[0].Before != 2 || c.Differences[0].After != 3 {
+		t.Errorf("expected the replicas to differ from the second to the seventh snapshot, got %+v", c.Differences)
+	}
+
+	var md strings.Builder
+	if err := c.WriteMarkdown(&md); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"| Time at max size | 5m0s | 0s | -5m0s |",
+		"| Instance-hours | 0.20 | 0.30 | +0.10 |",
+		"| 2025-01-01T00:06:00Z | 1 | 2 |",
+	} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("expected the comparison to contain %q, got:\n%s", want, md.String())
+		}
+	}
+
+	if _, err := r.Replay(&autoscale.GetResponseItem{Name: "web"}); err == nil {
+		t.Error("expected an error for a configuration without size limits")
+	}
+}
+
+func TestCompareLocations(t *testing.T) {
+	utc := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cet := utc.In(time.FixedZone("CET", 60*60))
+
+	before := &replay.Result{Simulation: &autoscale.Simulation{
+		Points: []autoscale.SimulationPoint{{Time: utc, Replicas: 1}},
+	}}
+	after := &replay.Result{Simulation: &autoscale.Simulation{
+		Points: []autoscale.SimulationPoint{{Time: cet, Replicas: 2}},
+	}}
+
+	c := replay.Compare(before, after)
+	if len(c.Differences) != 1 || c.Differences[0].Before != 1 || c.Differences[0].After != 2 {
+		t.Errorf("expected points of the same instant to be matched, got %+v", c.Differences)
+	}
+}
+
+func TestTraceObservations(t *testing.T) {
+	tr := trace(1, 2, 3)
+	tr[0].Instances[0].CPUTimeMs = 1000
+	tr[1].Instances[0].CPUTimeMs = 31000
+
+	// The instance was restarted between the last two snapshots.
+	tr[2].Instances[0].StartCount = 1
+	tr[2].Instances[0].CPUTimeMs = 6000
+
+	obs := tr.Observations()
+
+	if _, ok := obs[0].Values[autoscale.PolicyMetricCPU]; ok {
+		t.Error("expected no CPU utilization for the first snapshot")
+	}
+	if cpu := obs[1].Values[autoscale.PolicyMetricCPU]; math.Abs(cpu-50) > 1e-9 {
+		t.Errorf("expected 50%% CPU utilization, got %f", cpu)
+	}
+	if cpu := obs[2].Values[autoscale.PolicyMetricCPU]; math.Abs(cpu-10) > 1e-9 {
+		t.Errorf("expected 10%% CPU utilization after the restart, got %f", cpu)
+	}
+	if reqs := obs[2].Values[autoscale.PolicyMetricInflightRequests]; reqs != 3 {
+		t.Errorf("expected 3 in-flight requests, got %f", reqs)
+	}
+}
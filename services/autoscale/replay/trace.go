@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/instances"
+	"sdk.kraft.cloud/services/autoscale"
+)
+
+// Snapshot is the metrics of the instances of a service group at a point in
+// time.
+type Snapshot struct {
+	Time      time.Time                       `json:"time"`
+	Instances []instances.MetricsResponseItem `json:"instances"`
+}
+
+// Trace is a series of snapshots, ordered by time.  It can be stored as JSON
+// and replayed later.
+type Trace []Snapshot
+
+// Record takes a snapshot of the metrics of the referenced instances.
+func Record(ctx context.Context, client instances.InstancesService, ids ...kcclient.Ref) (Snapshot, error) {
+	resp, err := client.Metrics(ctx, ids...)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("getting metrics: %w", err)
+	}
+
+	items, err := resp.AllOrErr()
+	if len(items) == 0 && err != nil {
+		return Snapshot{}, fmt.Errorf("getting metrics: %w", err)
+	}
+
+	snapshot := Snapshot{
+		Time:      time.Now(),
+		Instances: make([]instances.MetricsResponseItem, 0, len(items)),
+	}
+	for _, item := range items {
+		if item.Error == nil {
+			snapshot.Instances = append(snapshot.Instances, item)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Observations returns the total load of the service group at each snapshot:
+// the sum of the in-flight requests and the sum of the CPU utilization of all
+// instances, in percent of a single CPU.
+//
+// The CPU utilization is derived from the CPU time consumed since the
+// previous snapshot, hence the first snapshot has no CPU value.  The CPU time
+// of an instance which was (re)started in between is counted from its start.
+func (t Trace) Observations() []autoscale.Observation {
+	observations := make([]autoscale.Observation, 0, len(t))
+
+	for i, snapshot := range t {
+		obs := autoscale.Observation{
+			Time:   snapshot.Time,
+			Values: make(map[autoscale.PolicyMetric]float64, 2),
+		}
+
+		var requests float64
+		for _, item := range snapshot.Instances {
+			requests += float64(item.Requests)
+		}
+		obs.Values[autoscale.PolicyMetricInflightRequests] = requests
+
+		if i > 0 {
+			if cpu, ok := utilization(t[i-1], snapshot); ok {
+				obs.Values[autoscale.PolicyMetricCPU] = cpu
+			}
+		}
+
+		observations = append(observations, obs)
+	}
+
+	return observations
+}
+
+// utilization returns the summed CPU utilization of the instances between two
+// snapshots, in percent of a single CPU.
+func utilization(prev, cur Snapshot) (float64, bool) {
+	elapsed := cur.Time.Sub(prev.Time)
+	if elapsed <= 0 {
+		return 0, false
+	}
+
+	before := make(map[string]instances.MetricsResponseItem, len(prev.Instances))
+	for _, item := range prev.Instances {
+		before[item.UUID] = item
+	}
+
+	var cpu float64
+	for _, item := range cur.Instances {
+		used := item.CPUTimeMs
+		if b, ok := before[item.UUID]; ok && b.StartCount == item.StartCount && b.CPUTimeMs <= item.CPUTimeMs {
+			used -= b.CPUTimeMs
+		}
+
+		cpu += float64(time.Duration(used)*time.Millisecond) / float64(elapsed) * 100
+	}
+
+	return cpu, true
+}
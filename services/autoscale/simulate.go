@@ -0,0 +1,295 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package autoscale
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// Sample is a value of the metric of a policy at a point in time.
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// SimulationConfig describes the service group whose replica count is
+// simulated.
+type SimulationConfig struct {
+	// MinSize and MaxSize bound the number of replicas.
+	MinSize int `json:"min_size"`
+	MaxSize int `json:"max_size"`
+
+	// InitialSize is the number of ready replicas at the first sample.  It
+	// defaults to MinSize.
+	InitialSize int `json:"initial_size,omitempty"`
+
+	// Warmup is the time after which added replicas are ready.  Until then,
+	// they count towards the size of the service group but do not take load.
+	Warmup time.Duration `json:"warmup"`
+
+	// Cooldown is the time after a scale event during which the policy is not
+	// evaluated.
+	Cooldown time.Duration `json:"cooldown"`
+
+	// Total reports that the samples are the total load of the service group,
+	// e.g. the sum of the in-flight requests of all instances.  The metric is
+	// then the total divided among the ready replicas, so that scaling out
+	// lowers it.  Otherwise the samples are taken as the metric as is.
+	Total bool `json:"total,omitempty"`
+}
+
+// Observation is the value of each policy metric at a point in time.
+type Observation struct {
+	Time   time.Time                `json:"time"`
+	Values map[PolicyMetric]float64 `json:"values"`
+}
+
+// SimulationPoint is the simulated state of the service group at a sample.
+type SimulationPoint struct {
+	// Time of the sample.
+	Time time.Time `json:"time"`
+
+	// Policy is the name of the policy which determined the number of
+	// replicas, or empty if no step matched.
+	Policy string `json:"policy,omitempty"`
+
+	// Metric is the value which the policy was evaluated against.
+	Metric float64 `json:"metric"`
+
+	// Step is the index of the matching step, or -1 if no step matched.
+	Step int `json:"step"`
+
+	// Replicas is the number of replicas after the policy was applied.
+	Replicas int `json:"replicas"`
+
+	// Ready is the number of replicas which have completed their warmup.
+	Ready int `json:"ready"`
+
+	// Cooldown is true if the policy was not evaluated due to a cooldown.
+	Cooldown bool `json:"cooldown,omitempty"`
+}
+
+// ScaleEvent is a change of the number of replicas.
+type ScaleEvent struct {
+	// Time of the change.
+	Time time.Time `json:"time"`
+
+	// From and To are the number of replicas before and after the change.
+	From int `json:"from"`
+	To   int `json:"to"`
+
+	// Policy is the name of the policy which triggered the change.
+	Policy string `json:"policy,omitempty"`
+
+	// Metric is the value which triggered the change.
+	Metric float64 `json:"metric"`
+
+	// Step is the index of the step which triggered the change.
+	Step int `json:"step"`
+}
+
+// Simulation is the predicted behaviour of a policy over a series of samples.
+type Simulation struct {
+	Points []SimulationPoint `json:"points"`
+	Events []ScaleEvent      `json:"events"`
+}
+
+// Simulate predicts the number of replicas of a service group over time when
+// the policy is applied to the samples, which are sorted by time first.  The
+// policy must be valid.
+//
+// A step matches if the metric is at least its lower bound and below its upper
+// bound.  Absolute adjustments set the number of replicas, changes add to it
+// and percentages add the given share of it, rounded away from zero.  The
+// result is clamped to the size limits of the configuration.
+func Simulate(policy StepPolicy, samples []Sample, config SimulationConfig) (*Simulation, error) {
+	observations := make([]Observation, len(samples))
+	for i, sample := range samples {
+		observations[i] = Observation{
+			Time:   sample.Time,
+			Values: map[PolicyMetric]float64{policy.Metric: sample.Value},
+		}
+	}
+
+	return SimulatePolicies([]StepPolicy{policy}, observations, config)
+}
+
+// SimulatePolicies is like Simulate, but evaluates several policies at each
+// observation.  A policy is skipped if the observation has no value for its
+// metric.  If the policies disagree, the one asking for the most replicas
+// wins, so that scaling in never starves the load measured by another
+// policy.
+func SimulatePolicies(policies []StepPolicy, observations []Observation, config SimulationConfig) (*Simulation, error) {
+	if len(policies) == 0 {
+		return nil, errors.New("requires at least one policy")
+	}
+	for _, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.MaxSize < 1 || config.MinSize < 0 || config.MaxSize < config.MinSize {
+		return nil, fmt.Errorf("invalid size limits [%d, %d]", config.MinSize, config.MaxSize)
+	}
+	if config.InitialSize == 0 {
+		config.InitialSize = config.MinSize
+	}
+	if config.InitialSize < config.MinSize || config.InitialSize > config.MaxSize {
+		return nil, fmt.Errorf("initial size %d is outside of the size limits [%d, %d]", config.InitialSize, config.MinSize, config.MaxSize)
+	}
+	if len(observations) == 0 {
+		return nil, errors.New("requires at least one sample")
+	}
+
+	observations = slices.Clone(observations)
+	slices.SortStableFunc(observations, func(a, b Observation) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	var (
+		sim      = &Simulation{Points: make([]SimulationPoint, 0, len(observations))}
+		ready    = config.InitialSize
+		warming  []time.Time // ready times of warming replicas, in order
+		cooldown time.Time
+	)
+
+	// promote marks the replicas whose warmup has completed as ready.
+	promote := func(t time.Time) {
+		for len(warming) > 0 && !warming[0].After(t) {
+			warming = warming[1:]
+			ready++
+		}
+	}
+
+	// metric returns the value of the observation which the policy is
+	// evaluated against.
+	metric := func(obs Observation, policy StepPolicy) (float64, bool) {
+		value, ok := obs.Values[policy.Metric]
+		if ok && config.Total && ready > 0 {
+			value /= float64(ready)
+		}
+		return value, ok
+	}
+
+	for _, obs := range observations {
+		promote(obs.Time)
+
+		replicas := ready + len(warming)
+
+		point := SimulationPoint{
+			Time: obs.Time,
+			Step: -1,
+		}
+		for _, policy := range policies {
+			if value, ok := metric(obs, policy); ok {
+				point.Metric = value
+				break
+			}
+		}
+
+		if obs.Time.Before(cooldown) {
+			point.Cooldown = true
+		} else {
+			var (
+				desired int
+				matched bool
+			)
+			for _, policy := range policies {
+				value, ok := metric(obs, policy)
+				if !ok {
+					continue
+				}
+
+				step := policy.match(value)
+				if step < 0 {
+					continue
+				}
+
+				n := policy.apply(policy.Steps[step].Adjustment, replicas)
+				n = min(max(n, config.MinSize), config.MaxSize)
+
+				if !matched || n > desired {
+					desired = n
+					matched = true
+					point.Policy = policy.Name
+					point.Metric = value
+					point.Step = step
+				}
+			}
+
+			if matched && desired != replicas {
+				sim.Events = append(sim.Events, ScaleEvent{
+					Time:   obs.Time,
+					From:   replicas,
+					To:     desired,
+					Policy: point.Policy,
+					Metric: point.Metric,
+					Step:   point.Step,
+				})
+
+				if desired > replicas {
+					for range desired - replicas {
+						warming = append(warming, obs.Time.Add(config.Warmup))
+					}
+				} else {
+					// Warming replicas are removed first.
+					remove := replicas - desired
+					drop := min(remove, len(warming))
+					warming = warming[:len(warming)-drop]
+					ready -= remove - drop
+				}
+
+				cooldown = obs.Time.Add(config.Cooldown)
+			}
+		}
+
+		promote(obs.Time)
+		point.Ready = ready
+		point.Replicas = ready + len(warming)
+
+		sim.Points = append(sim.Points, point)
+	}
+
+	return sim, nil
+}
+
+// match returns the index of the step whose bounds contain the value, or -1.
+func (p StepPolicy) match(value float64) int {
+	for i, step := range p.Steps {
+		if step.LowerBound != nil && value < float64(*step.LowerBound) {
+			continue
+		}
+		if step.UpperBound != nil && value >= float64(*step.UpperBound) {
+			continue
+		}
+
+		return i
+	}
+
+	return -1
+}
+
+// apply returns the number of replicas after applying the adjustment to the
+// current number of replicas.
+func (p StepPolicy) apply(adjustment, replicas int) int {
+	switch p.AdjustmentType {
+	case AdjustmentTypeAbsolute:
+		return adjustment
+	case AdjustmentTypePercent:
+		delta := float64(replicas) * float64(adjustment) / 100
+		if delta < 0 {
+			return replicas + int(math.Floor(delta))
+		}
+		return replicas + int(math.Ceil(delta))
+	default:
+		return replicas + adjustment
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package autoscale_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"sdk.kraft.cloud/services/autoscale"
+)
+
+func TestSimulate(t *testing.T) {
+	policy := autoscale.StepPolicy{
+		Name:           "reqs",
+		Metric:         autoscale.PolicyMetricInflightRequests,
+		AdjustmentType: autoscale.AdjustmentTypeChange,
+		Steps: []autoscale.Step{
+			{Adjustment: -1, UpperBound: bound(5)},
+			{Adjustment: 0, LowerBound: bound(5), UpperBound: bound(20)},
+			{Adjustment: 2, LowerBound: bound(20)},
+		},
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var samples []autoscale.Sample
+	for i, total := range []float64{50, 50, 50, 50, 50, 10, 10} {
+		samples = append(samples, autoscale.Sample{Time: start.Add(time.Duration(i) * 15 * time.Second), Value: total})
+	}
+
+	// Samples are sorted before they are replayed.
+	slices.Reverse(samples)
+
+	sim, err := autoscale.Simulate(policy, samples, autoscale.SimulationConfig{
+		MinSize:  1,
+		MaxSize:  4,
+		Warmup:   30 * time.Second,
+		Cooldown: time.Minute,
+		Total:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var replicas, ready []int
+	for _, point := range sim.Points {
+		replicas = append(replicas, point.Replicas)
+		ready = append(ready, point.Ready)
+	}
+
+	if !slices.Equal(replicas, []int{3, 3, 3, 3, 3, 2, 2}) {
+		t.Errorf("unexpected replicas %v", replicas)
+	}
+	if !slices.Equal(ready, []int{1, 1, 3, 3, 3, 2, 2}) {
+		t.Errorf("expected added replicas to become ready after the warmup, got %v", ready)
+	}
+
+	if len(sim.Events) != 2 || sim.Events[0].To != 3 || sim.Events[1].Time != start.Add(75*time.Second) || sim.Events[1].To != 2 {
+		t.Errorf("expected a scale out and a scale in after the cooldown, got %+v", sim.Events)
+	}
+
+	if _, err := autoscale.Simulate(policy, samples, autoscale.SimulationConfig{MinSize: 3, MaxSize: 2}); err == nil {
+		t.Error("expected an error for invalid size limits")
+	}
+}
+
+func TestSimulateScaleInPastZero(t *testing.T) {
+	policy := autoscale.StepPolicy{
+		Name:           "cpu",
+		Metric:         autoscale.PolicyMetricCPU,
+		AdjustmentType: autoscale.AdjustmentTypeChange,
+		Steps: []autoscale.Step{
+			{Adjustment: -5, UpperBound: bound(20)},
+			{Adjustment: 0, LowerBound: bound(20)},
+		},
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sim, err := autoscale.Simulate(policy, []autoscale.Sample{{Time: start, Value: 5}}, autoscale.SimulationConfig{
+		MinSize:     1,
+		MaxSize:     4,
+		InitialSize: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if point := sim.Points[0]; point.Step != 0 || point.Replicas != 1 {
+		t.Errorf("expected the first step to scale in to the minimum size, got %+v", point)
+	}
+	if len(sim.Events) != 1 || sim.Events[0].From != 3 || sim.Events[0].To != 1 {
+		t.Errorf("expected a scale in from 3 to 1 replicas, got %+v", sim.Events)
+	}
+}
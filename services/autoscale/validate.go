@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package autoscale
+
+import (
+	"fmt"
+	"slices"
+
+	"sdk.kraft.cloud/internal/validation"
+)
+
+// FieldError is the error of a single field of a configuration or policy.
+type FieldError = validation.FieldError
+
+// ValidationError contains the errors of all invalid fields of a
+// configuration or policy.
+type ValidationError = validation.Error
+
+// Validate checks the configuration and its step policies, so that mistakes
+// are reported before any request is sent.  The returned error is a
+// *ValidationError listing every invalid field.
+func (r CreateRequest) Validate() error {
+	v := validation.NewValidator("autoscale configuration")
+
+	if r.MinSize != nil && *r.MinSize < 0 {
+		v.Addf("min_size", "must not be negative, got %d", *r.MinSize)
+	}
+	if r.MaxSize != nil && *r.MaxSize < 1 {
+		v.Addf("max_size", "must be at least 1, got %d", *r.MaxSize)
+	}
+	if r.MinSize != nil && r.MaxSize != nil && *r.MaxSize < *r.MinSize {
+		v.Addf("max_size", "must not be lower than the minimum size (%d), got %d", *r.MinSize, *r.MaxSize)
+	}
+	if r.WarmupTimeMs != nil && *r.WarmupTimeMs < 0 {
+		v.Addf("warmup_time_ms", "must not be negative, got %d", *r.WarmupTimeMs)
+	}
+	if r.CooldownTimeMs != nil && *r.CooldownTimeMs < 0 {
+		v.Addf("cooldown_time_ms", "must not be negative, got %d", *r.CooldownTimeMs)
+	}
+
+	names := make(map[string]int)
+	for i, policy := range r.Policies {
+		field := fmt.Sprintf("policies[%d]", i)
+
+		var name string
+		switch p := policy.(type) {
+		case StepPolicy:
+			name = p.Name
+			p.validate(v, field+".")
+		case *StepPolicy:
+			name = p.Name
+			p.validate(v, field+".")
+		case OnDemandPolicy:
+			name = p.Name
+		case *OnDemandPolicy:
+			name = p.Name
+		}
+
+		if j, ok := names[name]; ok && name != "" {
+			v.Addf(field+".name", "duplicates the name of policies[%d]: '%s'", j, name)
+		}
+		names[name] = i
+	}
+
+	return v.Err()
+}
+
+// Validate checks the policy locally: its metric and adjustment type must be
+// known, its steps must be ordered by their bounds without gaps or overlaps,
+// and the adjustments must be sensible for the adjustment type and must not
+// decrease as the metric increases.  The returned error is a *ValidationError
+// listing every invalid field.
+func (p StepPolicy) Validate() error {
+	v := validation.NewValidator("autoscale configuration")
+	p.validate(v, "")
+	return v.Err()
+}
+
+// validate adds the errors of the policy, whose fields are prefixed with the
+// given path, to the validator.
+func (p StepPolicy) validate(v *validation.Validator, path string) {
+	if p.Name == "" {
+		v.Addf(path+"name", "must not be empty")
+	}
+
+	if !slices.Contains([]PolicyMetric{PolicyMetricCPU, PolicyMetricInflightRequests}, p.Metric) {
+		v.Addf(path+"metric", "must be '%s' or '%s', got '%s'", PolicyMetricCPU, PolicyMetricInflightRequests, p.Metric)
+	}
+
+	switch p.AdjustmentType {
+	case AdjustmentTypePercent, AdjustmentTypeAbsolute, AdjustmentTypeChange:
+	default:
+		v.Addf(path+"adjustment_type", "must be '%s', '%s' or '%s', got '%s'",
+			AdjustmentTypePercent, AdjustmentTypeAbsolute, AdjustmentTypeChange, p.AdjustmentType)
+	}
+
+	if len(p.Steps) == 0 {
+		v.Addf(path+"steps", "requires at least one step")
+		return
+	}
+
+	for i, step := range p.Steps {
+		field := fmt.Sprintf("%ssteps[%d]", path, i)
+
+		if step.LowerBound == nil && i > 0 {
+			v.Addf(field+".lower_bound", "may only be omitted on the first step")
+		}
+		if step.UpperBound == nil && i < len(p.Steps)-1 {
+			v.Addf(field+".upper_bound", "may only be omitted on the last step")
+		}
+		if step.LowerBound != nil && *step.LowerBound < 0 {
+			v.Addf(field+".lower_bound", "must not be negative, got %d", *step.LowerBound)
+		}
+		if step.LowerBound != nil && step.UpperBound != nil && *step.UpperBound <= *step.LowerBound {
+			v.Addf(field+".upper_bound", "must be greater than the lower bound (%d), got %d", *step.LowerBound, *step.UpperBound)
+		}
+
+		switch p.AdjustmentType {
+		case AdjustmentTypeAbsolute:
+			if step.Adjustment < 0 {
+				v.Addf(field+".adjustment", "must not be negative for absolute adjustments, got %d", step.Adjustment)
+			}
+		case AdjustmentTypePercent:
+			if step.Adjustment < -100 {
+				v.Addf(field+".adjustment", "must not remove more than 100 percent, got %d", step.Adjustment)
+			}
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		prev := p.Steps[i-1]
+		if prev.UpperBound != nil && step.LowerBound != nil {
+			switch {
+			case *step.LowerBound > *prev.UpperBound:
+				v.Addf(field+".lower_bound", "leaves a gap after the previous step, which ends at %d, got %d", *prev.UpperBound, *step.LowerBound)
+			case *step.LowerBound < *prev.UpperBound:
+				v.Addf(field+".lower_bound", "overlaps the previous step, which ends at %d, got %d", *prev.UpperBound, *step.LowerBound)
+			}
+		}
+
+		if step.Adjustment < prev.Adjustment {
+			v.Addf(field+".adjustment", "must not be lower than the adjustment of the previous step (%d) for a higher metric, got %d", prev.Adjustment, step.Adjustment)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package autoscale_test
+
+import (
+	"errors"
+	"testing"
+
+	"sdk.kraft.cloud/services/autoscale"
+)
+
+func bound(n int) *int {
+	return &n
+}
+
+func TestStepPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy autoscale.StepPolicy
+		fields []string
+	}{
+		{
+			name: "valid",
+			policy: autoscale.StepPolicy{
+				Name:           "cpu",
+				Metric:         autoscale.PolicyMetricCPU,
+				AdjustmentType: autoscale.AdjustmentTypeChange,
+				Steps: []autoscale.Step{
+					{Adjustment: -1, UpperBound: bound(20)},
+					{Adjustment: 0, LowerBound: bound(20), UpperBound: bound(60)},
+					{Adjustment: 2, LowerBound: bound(60)},
+				},
+			},
+		},
+		{
+			name: "gap and overlap",
+			policy: autoscale.StepPolicy{
+				Name:           "reqs",
+				Metric:         autoscale.PolicyMetricInflightRequests,
+				AdjustmentType: autoscale.AdjustmentTypeChange,
+				Steps: []autoscale.Step{
+					{Adjustment: -1, UpperBound: bound(10)},
+					{Adjustment: 0, LowerBound: bound(15), UpperBound: bound(50)},
+					{Adjustment: 1, LowerBound: bound(40)},
+				},
+			},
+			fields: []string{"steps[1].lower_bound", "steps[2].lower_bound"},
+		},
+		{
+			name: "nonsensical adjustments",
+			policy: autoscale.StepPolicy{
+				Name:           "abs",
+				Metric:         "memory",
+				AdjustmentType: autoscale.AdjustmentTypeAbsolute,
+				Steps: []autoscale.Step{
+					{Adjustment: 4, UpperBound: bound(50)},
+					{Adjustment: -1, LowerBound: bound(50), UpperBound: bound(50)},
+				},
+			},
+			fields: []string{"metric", "steps[1].upper_bound", "steps[1].adjustment", "steps[1].adjustment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+
+			var verr *autoscale.ValidationError
+			if len(tt.fields) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+
+			var fields []string
+			for _, ferr := range verr.Errors {
+				fields = append(fields, ferr.Field)
+			}
+			if len(fields) != len(tt.fields) {
+				t.Fatalf("expected errors for %v, got %v", tt.fields, err)
+			}
+			for i := range fields {
+				if fields[i] != tt.fields[i] {
+					t.Errorf("expected errors for %v, got %v", tt.fields, err)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package services
+
+import kcclient "sdk.kraft.cloud/client"
+
+// CreateRequestBuilder builds a CreateRequest with a fluent interface, e.g.:
+//
+//	req, err := services.NewCreateRequestBuilder().
+//		Name("my-service").
+//		HTTPS(8080).
+//		HTTPRedirect().
+//		Domain("example.com").
+//		Build()
+type CreateRequestBuilder struct {
+	req CreateRequest
+}
+
+// NewCreateRequestBuilder instantiates a new, empty CreateRequestBuilder.
+func NewCreateRequestBuilder() *CreateRequestBuilder {
+	return &CreateRequestBuilder{}
+}
+
+// Name sets the name of the service group.
+func (b *CreateRequestBuilder) Name(name string) *CreateRequestBuilder {
+	b.req.Name = &name
+	return b
+}
+
+// Port publishes the port with the given handlers.  Connections are forwarded
+// to the same port of the instances.
+func (b *CreateRequestBuilder) Port(port int, handlers ...Handler) *CreateRequestBuilder {
+	b.req.Services = append(b.req.Services, CreateRequestService{
+		Port:     port,
+		Handlers: handlers,
+	})
+	return b
+}
+
+// PortTo publishes the port with the given handlers.  Connections are
+// forwarded to the destination port of the instances.
+func (b *CreateRequestBuilder) PortTo(port, destPort int, handlers ...Handler) *CreateRequestBuilder {
+	b.req.Services = append(b.req.Services, CreateRequestService{
+		Port:            port,
+		DestinationPort: &destPort,
+		Handlers:        handlers,
+	})
+	return b
+}
+
+// HTTPS publishes port 443, terminating TLS and load balancing HTTP requests
+// to the destination port of the instances.
+func (b *CreateRequestBuilder) HTTPS(destPort int) *CreateRequestBuilder {
+	return b.PortTo(443, destPort, HandlerTLS, HandlerHTTP)
+}
+
+// HTTPRedirect publishes port 80, redirecting HTTP requests to port 443.
+func (b *CreateRequestBuilder) HTTPRedirect() *CreateRequestBuilder {
+	return b.PortTo(80, 443, HandlerHTTP, HandlerRedirect)
+}
+
+// TLS publishes the port, terminating TLS and forwarding TCP connections to
+// the destination port of the instances.
+func (b *CreateRequestBuilder) TLS(port, destPort int) *CreateRequestBuilder {
+	return b.PortTo(port, destPort, HandlerTLS)
+}
+
+// Domain adds a custom domain which uses an automatically issued certificate.
+func (b *CreateRequestBuilder) Domain(name string) *CreateRequestBuilder {
+	b.req.Domains = append(b.req.Domains, CreateRequestDomain{
+		Name: name,
+	})
+	return b
+}
+
+// DomainWithCertificate adds a custom domain which uses the referenced
+// certificate.
+func (b *CreateRequestBuilder) DomainWithCertificate(name string, certificate kcclient.Ref) *CreateRequestBuilder {
+	cert := certificateRef(certificate)
+
+	b.req.Domains = append(b.req.Domains, CreateRequestDomain{
+		Name:        name,
+		Certificate: &cert,
+	})
+	return b
+}
+
+// SoftLimit sets the number of concurrent requests per instance above which
+// the load balancer prefers other instances.
+func (b *CreateRequestBuilder) SoftLimit(limit int) *CreateRequestBuilder {
+	b.req.SoftLimit = &limit
+	return b
+}
+
+// HardLimit sets the maximum number of concurrent requests per instance.
+func (b *CreateRequestBuilder) HardLimit(limit int) *CreateRequestBuilder {
+	b.req.HardLimit = &limit
+	return b
+}
+
+// Build validates and returns the request.  The error is a *ValidationError
+// listing every invalid field.
+func (b *CreateRequestBuilder) Build() (CreateRequest, error) {
+	req := b.req
+	req.Services = append([]CreateRequestService(nil), b.req.Services...)
+	req.Domains = append([]CreateRequestDomain(nil), b.req.Domains...)
+
+	if err := req.Validate(); err != nil {
+		return CreateRequest{}, err
+	}
+
+	return req, nil
+}
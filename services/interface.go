@@ -31,7 +31,15 @@ type ServicesService interface {
 	// Get returns the current state and the configuration of service groups.
 	//
 	// See: https://docs.kraft.cloud/api/v1/services/#getting-the-state-of-a-service-group
-	Get(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[GetResponseItem], error)
+	Get(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[GetResponseItem], error)
+
+	// Patch applies the operations to the service group with the given
+	// identifier, e.g. to add a domain or to change the limits.  The operations
+	// are validated with ValidatePatch before any request is sent.  Use the
+	// operation constructors, e.g. AddDomain, or a PatchBuilder.
+	//
+	// See: https://docs.kraft.cloud/api/v1/services/#update-a-service
+	Patch(ctx context.Context, id kcclient.Ref, ops ...PatchOperation) (*kcclient.ServiceResponse[PatchResponseItem], error)
 
 	// Delete deletes the specified service group(s).
 	// Fails if there are still instances attached to any of the specified
@@ -40,7 +48,7 @@ type ServicesService interface {
 	// This operation cannot be undone.
 	//
 	// See: https://docs.kraft.cloud/api/v1/services/#deleting-a-service-group
-	Delete(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[DeleteResponseItem], error)
+	Delete(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[DeleteResponseItem], error)
 
 	// Lists all existing service groups. You can filter by persistence and DNS
 	// name. The latter can be used to lookup the UUID of the service group that
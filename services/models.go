@@ -33,11 +33,13 @@ type CreateRequestService struct {
 	Handlers        []Handler `json:"handlers,omitempty"`
 }
 
-// PatchRequest is the payload for a PATCH /services request.
+// PatchRequest is the payload for a PATCH /services request.  Each request
+// applies a single PatchOperation to the group identified by UUID or Name.
 // https://docs.kraft.cloud/api/v1/services/#update-a-service
 type PatchRequest struct {
 	UUID  string  `json:"uuid,omitempty"`
 	Name  *string `json:"name,omitempty"`
+	Prop  *string `json:"prop,omitempty"`
 	Op    *string `json:"op,omitempty"`
 	Value *any    `json:"value,omitempty"`
 	ID    *any    `json:"id,omitempty"`
@@ -54,13 +56,33 @@ type CreateResponseItem struct {
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i CreateResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
+// PatchResponseItem is a data item from a response to a PATCH /services
+// request.  It reflects the group after the operations were applied.
+// https://docs.kraft.cloud/api/v1/services/#update-a-service
+type PatchResponseItem struct {
+	Status    string                    `json:"status"`
+	UUID      string                    `json:"uuid"`
+	Name      string                    `json:"name"`
+	Services  []GetResponseService      `json:"services"`
+	Domains   []GetCreateResponseDomain `json:"domains"`
+	SoftLimit int                       `json:"soft_limit"`
+	HardLimit int                       `json:"hard_limit"`
+
+	kcclient.APIResponseCommon
+}
+
 // GetResponseItem is a data item from a response to a GET /services request.
 // https://docs.kraft.cloud/api/v1/services/#getting-the-status-of-a-service-group
 type GetResponseItem struct {
 	Status     string                    `json:"status"`
 	UUID       string                    `json:"uuid"`
 	Name       string                    `json:"name"`
-	CreatedAt  string                    `json:"created_at"`
+	CreatedAt  kcclient.Timestamp        `json:"created_at"`
 	Persistent bool                      `json:"persistent"`
 	Autoscale  bool                      `json:"autoscale"`
 	Services   []GetResponseService      `json:"services"`
@@ -72,6 +94,11 @@ type GetResponseItem struct {
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i GetResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
 type GetCreateResponseDomain struct {
 	FQDN        string                              `json:"fqdn"`
 	Certificate *GetCreateResponseDomainCertificate `json:"certificate"`
@@ -0,0 +1,386 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package services
+
+import (
+	"fmt"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/internal/validation"
+)
+
+// PatchProp is a property of a service group which can be patched.
+type PatchProp string
+
+const (
+	// The published ports, whose elements are identified by their port.
+	PatchPropServices PatchProp = "services"
+
+	// The custom domains, whose elements are identified by their name.
+	PatchPropDomains PatchProp = "domains"
+
+	// The certificate of a custom domain, identified by the name of the domain.
+	PatchPropCertificate PatchProp = "certificate"
+
+	// The soft and hard limits of concurrent requests per instance.
+	PatchPropSoftLimit PatchProp = "soft_limit"
+	PatchPropHardLimit PatchProp = "hard_limit"
+)
+
+// PatchOp is the operation applied to a property.
+type PatchOp string
+
+const (
+	// Set the value of a property.
+	PatchOpSet PatchOp = "set"
+
+	// Add an element to a list property.
+	PatchOpAdd PatchOp = "add"
+
+	// Remove the element with the given ID from a list property.
+	PatchOpDel PatchOp = "del"
+)
+
+// PatchOperation is a single change of a service group.  Use the constructors,
+// e.g. AddDomain, or a PatchBuilder to create operations with the value types
+// expected by the API.
+type PatchOperation struct {
+	// Prop is the patched property.
+	Prop PatchProp
+
+	// Op is the operation applied to the property.
+	Op PatchOp
+
+	// ID identifies the element of the property, if the operation applies to a
+	// single element.
+	ID any
+
+	// Value is the new value or element of the property.
+	Value any
+}
+
+// request returns the payload of the operation for the referenced group.
+func (o PatchOperation) request(id kcclient.Ref) PatchRequest {
+	prop := string(o.Prop)
+	op := string(o.Op)
+
+	req := PatchRequest{
+		Prop: &prop,
+		Op:   &op,
+	}
+
+	if value := id.Value(); id.Attr() == "uuid" {
+		req.UUID = value
+	} else {
+		req.Name = &value
+	}
+
+	if o.ID != nil {
+		req.ID = &o.ID
+	}
+	if o.Value != nil {
+		req.Value = &o.Value
+	}
+
+	return req
+}
+
+// AddDomain adds a custom domain which uses an automatically issued
+// certificate.
+func AddDomain(name string) PatchOperation {
+	return PatchOperation{
+		Prop:  PatchPropDomains,
+		Op:    PatchOpAdd,
+		Value: CreateRequestDomain{Name: name},
+	}
+}
+
+// AddDomainWithCertificate adds a custom domain which uses the referenced
+// certificate.
+func AddDomainWithCertificate(name string, certificate kcclient.Ref) PatchOperation {
+	cert := certificateRef(certificate)
+
+	return PatchOperation{
+		Prop:  PatchPropDomains,
+		Op:    PatchOpAdd,
+		Value: CreateRequestDomain{Name: name, Certificate: &cert},
+	}
+}
+
+// RemoveDomain removes the custom domain with the given name.
+func RemoveDomain(name string) PatchOperation {
+	return PatchOperation{
+		Prop: PatchPropDomains,
+		Op:   PatchOpDel,
+		ID:   name,
+	}
+}
+
+// AddPort publishes a port.  See Handler for the constraints of the handlers.
+func AddPort(svc CreateRequestService) PatchOperation {
+	return PatchOperation{
+		Prop:  PatchPropServices,
+		Op:    PatchOpAdd,
+		Value: svc,
+	}
+}
+
+// RemovePort removes the published port.
+func RemovePort(port int) PatchOperation {
+	return PatchOperation{
+		Prop: PatchPropServices,
+		Op:   PatchOpDel,
+		ID:   port,
+	}
+}
+
+// SetSoftLimit sets the number of concurrent requests per instance above which
+// the load balancer prefers other instances.
+func SetSoftLimit(limit int) PatchOperation {
+	return PatchOperation{
+		Prop:  PatchPropSoftLimit,
+		Op:    PatchOpSet,
+		Value: limit,
+	}
+}
+
+// SetHardLimit sets the maximum number of concurrent requests per instance.
+func SetHardLimit(limit int) PatchOperation {
+	return PatchOperation{
+		Prop:  PatchPropHardLimit,
+		Op:    PatchOpSet,
+		Value: limit,
+	}
+}
+
+// AttachCertificate makes the custom domain with the given name use the
+// referenced certificate.
+func AttachCertificate(domain string, certificate kcclient.Ref) PatchOperation {
+	return PatchOperation{
+		Prop:  PatchPropCertificate,
+		Op:    PatchOpSet,
+		ID:    domain,
+		Value: certificateRef(certificate),
+	}
+}
+
+// DetachCertificate makes the custom domain with the given name use an
+// automatically issued certificate again.
+func DetachCertificate(domain string) PatchOperation {
+	return PatchOperation{
+		Prop: PatchPropCertificate,
+		Op:   PatchOpDel,
+		ID:   domain,
+	}
+}
+
+// certificateRef returns the payload which refers to the certificate.
+func certificateRef(certificate kcclient.Ref) CreateRequestDomainCertificate {
+	var cert CreateRequestDomainCertificate
+
+	if value := certificate.Value(); certificate.Attr() == "uuid" {
+		cert.UUID = &value
+	} else {
+		cert.Name = &value
+	}
+
+	return cert
+}
+
+// ValidatePatch checks the operations against the constraints of the
+// platform.  The returned error is a *ValidationError listing every invalid
+// field, where the fields of the operations are prefixed with ops[<index>].
+func ValidatePatch(ops ...PatchOperation) error {
+	v := validation.NewValidator("service group")
+
+	if len(ops) == 0 {
+		v.Addf("ops", "requires at least one operation")
+	}
+
+	var soft, hard *int
+	ports := make(map[int]int)
+	domains := make(map[string]int)
+
+	for i, o := range ops {
+		field := fmt.Sprintf("ops[%d]", i)
+
+		switch {
+		case o.Prop == PatchPropDomains && o.Op == PatchOpAdd:
+			domain, ok := o.Value.(CreateRequestDomain)
+			if !ok {
+				v.Addf(field+".value", "must be a CreateRequestDomain, got %T", o.Value)
+				continue
+			}
+
+			name := validateDomain(v, field+".value", domain)
+			if j, ok := domains[name]; ok && name != "" {
+				v.Addf(field+".value.name", "duplicates the domain of ops[%d]: %s", j, domain.Name)
+			} else {
+				domains[name] = i
+			}
+
+		case o.Prop == PatchPropDomains && o.Op == PatchOpDel,
+			o.Prop == PatchPropCertificate && o.Op == PatchOpDel:
+			validateDomainID(v, field+".id", o.ID)
+
+		case o.Prop == PatchPropCertificate && o.Op == PatchOpSet:
+			validateDomainID(v, field+".id", o.ID)
+
+			cert, ok := o.Value.(CreateRequestDomainCertificate)
+			if !ok {
+				v.Addf(field+".value", "must be a CreateRequestDomainCertificate, got %T", o.Value)
+				continue
+			}
+			validateCertificate(v, field+".value", cert)
+
+		case o.Prop == PatchPropServices && o.Op == PatchOpAdd:
+			svc, ok := o.Value.(CreateRequestService)
+			if !ok {
+				v.Addf(field+".value", "must be a CreateRequestService, got %T", o.Value)
+				continue
+			}
+
+			validateService(v, field+".value", svc)
+			if j, ok := ports[svc.Port]; ok {
+				v.Addf(field+".value.port", "duplicates the port of ops[%d]: %d", j, svc.Port)
+			} else {
+				ports[svc.Port] = i
+			}
+
+		case o.Prop == PatchPropServices && o.Op == PatchOpDel:
+			port, ok := o.ID.(int)
+			if !ok {
+				v.Addf(field+".id", "must be a port, got %T", o.ID)
+				continue
+			}
+			validatePort(v, field+".id", port)
+
+		case (o.Prop == PatchPropSoftLimit || o.Prop == PatchPropHardLimit) && o.Op == PatchOpSet:
+			limit, ok := o.Value.(int)
+			if !ok {
+				v.Addf(field+".value", "must be an int, got %T", o.Value)
+				continue
+			}
+			if limit < 1 {
+				v.Addf(field+".value", "must be at least 1, got %d", limit)
+			}
+
+			if o.Prop == PatchPropSoftLimit {
+				soft = &limit
+			} else {
+				hard = &limit
+			}
+
+		default:
+			v.Addf(field, "unsupported operation '%s' of property '%s'", o.Op, o.Prop)
+		}
+	}
+
+	if soft != nil && hard != nil && *hard < *soft {
+		v.Addf("ops", "hard limit (%d) must not be lower than the soft limit (%d)", *hard, *soft)
+	}
+
+	return v.Err()
+}
+
+// validateDomainID checks the identifier of a custom domain.
+func validateDomainID(v *validation.Validator, field string, id any) {
+	name, ok := id.(string)
+	if !ok {
+		v.Addf(field, "must be a domain name, got %T", id)
+		return
+	}
+
+	if normalizeDomain(name) == "" {
+		v.Addf(field, "must not be empty")
+	}
+}
+
+// PatchBuilder builds the operations of a patch with a fluent interface, e.g.:
+//
+//	ops, err := services.NewPatchBuilder().
+//		AddDomain("example.com").
+//		SetHardLimit(100).
+//		Build()
+type PatchBuilder struct {
+	ops []PatchOperation
+}
+
+// NewPatchBuilder instantiates a new, empty PatchBuilder.
+func NewPatchBuilder() *PatchBuilder {
+	return &PatchBuilder{}
+}
+
+// AddDomain adds an AddDomain operation.
+func (b *PatchBuilder) AddDomain(name string) *PatchBuilder {
+	b.ops = append(b.ops, AddDomain(name))
+	return b
+}
+
+// AddDomainWithCertificate adds an AddDomainWithCertificate operation.
+func (b *PatchBuilder) AddDomainWithCertificate(name string, certificate kcclient.Ref) *PatchBuilder {
+	b.ops = append(b.ops, AddDomainWithCertificate(name, certificate))
+	return b
+}
+
+// RemoveDomain adds a RemoveDomain operation.
+func (b *PatchBuilder) RemoveDomain(name string) *PatchBuilder {
+	b.ops = append(b.ops, RemoveDomain(name))
+	return b
+}
+
+// AddPort adds an AddPort operation which forwards connections to the
+// destination port of the instances.
+func (b *PatchBuilder) AddPort(port, destPort int, handlers ...Handler) *PatchBuilder {
+	b.ops = append(b.ops, AddPort(CreateRequestService{
+		Port:            port,
+		DestinationPort: &destPort,
+		Handlers:        handlers,
+	}))
+	return b
+}
+
+// RemovePort adds a RemovePort operation.
+func (b *PatchBuilder) RemovePort(port int) *PatchBuilder {
+	b.ops = append(b.ops, RemovePort(port))
+	return b
+}
+
+// SetSoftLimit adds a SetSoftLimit operation.
+func (b *PatchBuilder) SetSoftLimit(limit int) *PatchBuilder {
+	b.ops = append(b.ops, SetSoftLimit(limit))
+	return b
+}
+
+// SetHardLimit adds a SetHardLimit operation.
+func (b *PatchBuilder) SetHardLimit(limit int) *PatchBuilder {
+	b.ops = append(b.ops, SetHardLimit(limit))
+	return b
+}
+
+// AttachCertificate adds an AttachCertificate operation.
+func (b *PatchBuilder) AttachCertificate(domain string, certificate kcclient.Ref) *PatchBuilder {
+	b.ops = append(b.ops, AttachCertificate(domain, certificate))
+	return b
+}
+
+// DetachCertificate adds a DetachCertificate operation.
+func (b *PatchBuilder) DetachCertificate(domain string) *PatchBuilder {
+	b.ops = append(b.ops, DetachCertificate(domain))
+	return b
+}
+
+// Build validates and returns the operations.  The error is a
+// *ValidationError listing every invalid field.
+func (b *PatchBuilder) Build() ([]PatchOperation, error) {
+	ops := append([]PatchOperation(nil), b.ops...)
+
+	if err := ValidatePatch(ops...); err != nil {
+		return nil, err
+	}
+
+	return ops, nil
+}
@@ -9,6 +9,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -16,13 +17,26 @@ import (
 )
 
 // Patch implements ServicesService.
-func (c *client) Patch(ctx context.Context, req PatchRequest) (*kcclient.ServiceResponse[CreateResponseItem], error) {
-	body, err := json.Marshal(req)
+func (c *client) Patch(ctx context.Context, id kcclient.Ref, ops ...PatchOperation) (*kcclient.ServiceResponse[PatchResponseItem], error) {
+	if id.IsZero() {
+		return nil, errors.New("requires an identifier")
+	}
+
+	if err := ValidatePatch(ops...); err != nil {
+		return nil, err
+	}
+
+	reqs := make([]PatchRequest, len(ops))
+	for i, op := range ops {
+		reqs[i] = op.request(id)
+	}
+
+	body, err := json.Marshal(reqs)
 	if err != nil {
 		return nil, fmt.Errorf("error marshalling request body: %w", err)
 	}
 
-	resp := &kcclient.ServiceResponse[CreateResponseItem]{}
+	resp := &kcclient.ServiceResponse[PatchResponseItem]{}
 	if err := c.request.DoRequest(ctx, http.MethodPatch, Endpoint, bytes.NewReader(body), resp); err != nil {
 		return nil, fmt.Errorf("performing the request: %w", err)
 	}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kraftcloud "sdk.kraft.cloud"
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/services"
+)
+
+func TestPatch(t *testing.T) {
+	var body []map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/services" {
+			http.NotFound(w, r)
+			return
+		}
+
+		b, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Error(err)
+		}
+
+		_, _ = io.WriteString(w, `{"status":"success","data":{"service_groups":[`+
+			`{"status":"success","name":"web","domains":[{"fqdn":"example.com"}],"hard_limit":100}]}}`)
+	}))
+	defer srv.Close()
+
+	client := kraftcloud.NewServicesClient().WithMetro(srv.URL)
+
+	ops, err := services.NewPatchBuilder().
+		AddDomain("example.com").
+		RemovePort(8443).
+		AttachCertificate("example.com", kcclient.ByName("example-cert")).
+		SetHardLimit(100).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Patch(context.Background(), kcclient.ByName("web"), ops...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, err := resp.FirstOrErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group.HardLimit != 100 || len(group.Domains) != 1 {
+		t.Errorf("expected the patched group in the response, got %+v", group)
+	}
+
+	want := []string{
+		`{"name":"web","op":"add","prop":"domains","value":{"name":"example.com"}}`,
+		`{"id":8443,"name":"web","op":"del","prop":"services"}`,
+		`{"id":"example.com","name":"web","op":"set","prop":"certificate","value":{"name":"example-cert"}}`,
+		`{"name":"web","op":"set","prop":"hard_limit","value":100}`,
+	}
+	if len(body) != len(want) {
+		t.Fatalf("expected %d operations, got %d", len(want), len(body))
+	}
+	for i := range want {
+		got, _ := json.Marshal(body[i])
+		if string(got) != want[i] {
+			t.Errorf("operation %d: expected %s, got %s", i, want[i], got)
+		}
+	}
+}
+
+func TestPatchValidate(t *testing.T) {
+	client := kraftcloud.NewServicesClient().WithMetro("http://127.0.0.1:0")
+
+	_, err := client.Patch(context.Background(), kcclient.ByName("web"),
+		services.AddPort(services.CreateRequestService{Port: 8080, Handlers: []services.Handler{services.HandlerHTTP}}),
+		services.DetachCertificate(""),
+		services.SetSoftLimit(10),
+		services.SetHardLimit(5),
+	)
+
+	var verr *services.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+
+	fields := []string{"ops[0].value.handlers", "ops[0].value.handlers", "ops[1].id", "ops"}
+	if len(verr.Errors) != len(fields) {
+		t.Fatalf("expected errors of fields %v, got %v", fields, err)
+	}
+	for i, field := range fields {
+		if verr.Errors[i].Field != field {
+			t.Errorf("expected an error of field %s, got %v", field, verr.Errors[i])
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"sdk.kraft.cloud/internal/validation"
+)
+
+// FieldError is the error of a single field of a request.
+type FieldError = validation.FieldError
+
+// ValidationError contains the errors of all invalid fields of a request.
+type ValidationError = validation.Error
+
+// Validate checks the request against the constraints of the platform, so
+// that mistakes are reported before any request is sent.  The returned error
+// is a *ValidationError listing every invalid field.
+func (r CreateRequest) Validate() error {
+	v := validation.NewValidator("service group")
+
+	if r.Name != nil && *r.Name == "" {
+		v.Addf("name", "must not be empty")
+	}
+
+	validateServices(v, r.Services)
+	validateDomains(v, r.Domains)
+
+	if r.SoftLimit != nil && *r.SoftLimit < 1 {
+		v.Addf("soft_limit", "must be at least 1, got %d", *r.SoftLimit)
+	}
+	if r.HardLimit != nil && *r.HardLimit < 1 {
+		v.Addf("hard_limit", "must be at least 1, got %d", *r.HardLimit)
+	}
+	if r.SoftLimit != nil && r.HardLimit != nil && *r.HardLimit < *r.SoftLimit {
+		v.Addf("hard_limit", "must not be lower than the soft limit (%d), got %d", *r.SoftLimit, *r.HardLimit)
+	}
+
+	return v.Err()
+}
+
+// validateServices checks the published ports and their connection handlers.
+func validateServices(v *validation.Validator, services []CreateRequestService) {
+	ports := make(map[int]int, len(services))
+
+	for i, svc := range services {
+		field := fmt.Sprintf("services[%d]", i)
+
+		validateService(v, field, svc)
+
+		if j, ok := ports[svc.Port]; ok {
+			v.Addf(field+".port", "duplicates the port of services[%d]: %d", j, svc.Port)
+		} else {
+			ports[svc.Port] = i
+		}
+	}
+}
+
+// validateService checks a published port and its connection handlers.  See
+// Handler for the constraints.
+func validateService(v *validation.Validator, field string, svc CreateRequestService) {
+	validatePort(v, field+".port", svc.Port)
+
+	if svc.DestinationPort != nil {
+		validatePort(v, field+".destination_port", *svc.DestinationPort)
+	}
+
+	field += ".handlers"
+
+	seen := make(map[Handler]bool, len(svc.Handlers))
+	for _, h := range svc.Handlers {
+		if !slices.Contains(Handlers(), h) {
+			v.Addf(field, "unknown handler '%s'", h)
+		} else if seen[h] {
+			v.Addf(field, "duplicate handler '%s'", h)
+		}
+		seen[h] = true
+	}
+
+	tls, http, redirect := seen[HandlerTLS], seen[HandlerHTTP], seen[HandlerRedirect]
+
+	if redirect && !http {
+		v.Addf(field, "handler 'redirect' requires handler 'http'")
+	}
+	if redirect && svc.Port != 80 {
+		v.Addf(field, "handler 'redirect' is only allowed on port 80, got port %d", svc.Port)
+	}
+
+	switch svc.Port {
+	case 80:
+		if !http {
+			v.Addf(field, "port 80 requires handler 'http'")
+		}
+		if tls {
+			v.Addf(field, "port 80 must not have handler 'tls'")
+		}
+	case 443:
+		if !http || !tls {
+			v.Addf(field, "port 443 requires handlers 'tls' and 'http'")
+		}
+	default:
+		if !tls {
+			v.Addf(field, "port %d requires handler 'tls'", svc.Port)
+		}
+		if http {
+			v.Addf(field, "port %d must not have handler 'http'", svc.Port)
+		}
+	}
+}
+
+// validatePort checks that the port is in the valid range.
+func validatePort(v *validation.Validator, field string, port int) {
+	if port < 1 || port > 65535 {
+		v.Addf(field, "must be between 1 and 65535, got %d", port)
+	}
+}
+
+// validateDomains checks the custom domains and their certificates.
+func validateDomains(v *validation.Validator, domains []CreateRequestDomain) {
+	names := make(map[string]int, len(domains))
+
+	for i, domain := range domains {
+		field := fmt.Sprintf("domains[%d]", i)
+
+		name := validateDomain(v, field, domain)
+		if name == "" {
+			continue
+		}
+
+		if j, ok := names[name]; ok {
+			v.Addf(field+".name", "duplicates the name of domains[%d]: %s", j, domain.Name)
+		} else {
+			names[name] = i
+		}
+	}
+}
+
+// validateDomain checks a custom domain and its certificate, and returns the
+// normalized name of the domain.
+func validateDomain(v *validation.Validator, field string, domain CreateRequestDomain) string {
+	name := normalizeDomain(domain.Name)
+	if name == "" {
+		v.Addf(field+".name", "must not be empty")
+	}
+
+	if domain.Certificate != nil {
+		validateCertificate(v, field+".certificate", *domain.Certificate)
+	}
+
+	return name
+}
+
+// validateCertificate checks the reference to a certificate.
+func validateCertificate(v *validation.Validator, field string, cert CreateRequestDomainCertificate) {
+	hasUUID := cert.UUID != nil && *cert.UUID != ""
+	hasName := cert.Name != nil && *cert.Name != ""
+
+	if hasUUID == hasName {
+		v.Addf(field, "requires exactly one of uuid and name")
+	}
+}
+
+// normalizeDomain returns the name of the domain in lower case and without a
+// trailing dot.
+func normalizeDomain(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
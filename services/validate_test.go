@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: BSD-3-Clause
+// Copyright (c) 2025, Unikraft GmbH.
+// Licensed under the BSD-3-Clause License (the "License").
+// You may not use this file except in compliance with the License.
+
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	kcclient "sdk.kraft.cloud/client"
+	"sdk.kraft.cloud/services"
+)
+
+func TestCreateRequestBuilder(t *testing.T) {
+	req, err := services.NewCreateRequestBuilder().
+		Name("web").
+		HTTPS(8080).
+		HTTPRedirect().
+		TLS(5432, 5432).
+		DomainWithCertificate("example.com", kcclient.ByUUID("00000000-0000-0000-0000-000000000001")).
+		SoftLimit(10).
+		HardLimit(20).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(req.Services) != 3 || req.Services[1].Port != 80 || *req.Services[1].DestinationPort != 443 {
+		t.Errorf("unexpected services %+v", req.Services)
+	}
+	if cert := req.Domains[0].Certificate; cert == nil || cert.UUID == nil || cert.Name != nil {
+		t.Errorf("expected the certificate to be referenced by UUID, got %+v", cert)
+	}
+}
+
+func TestCreateRequestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *services.CreateRequestBuilder
+		fields  []string
+	}{
+		{
+			name:    "redirect without http",
+			builder: services.NewCreateRequestBuilder().PortTo(80, 443, services.HandlerRedirect),
+			fields:  []string{"services[0].handlers", "services[0].handlers"},
+		},
+		{
+			name:    "redirect on other port",
+			builder: services.NewCreateRequestBuilder().Port(8080, services.HandlerTLS, services.HandlerHTTP, services.HandlerRedirect),
+			fields:  []string{"services[0].handlers", "services[0].handlers"},
+		},
+		{
+			name:    "443 without tls",
+			builder: services.NewCreateRequestBuilder().Port(443, services.HandlerHTTP),
+			fields:  []string{"services[0].handlers"},
+		},
+		{
+			name:    "80 with tls",
+			builder: services.NewCreateRequestBuilder().Port(80, services.HandlerTLS, services.HandlerHTTP),
+			fields:  []string{"services[0].handlers"},
+		},
+		{
+			name:    "duplicate ports",
+			builder: services.NewCreateRequestBuilder().HTTPS(8080).HTTPS(8081),
+			fields:  []string{"services[1].port"},
+		},
+		{
+			name:    "unknown handler",
+			builder: services.NewCreateRequestBuilder().Port(5432, services.HandlerTLS, "udp"),
+			fields:  []string{"services[0].handlers"},
+		},
+		{
+			name:    "hard limit below soft limit",
+			builder: services.NewCreateRequestBuilder().HTTPS(8080).SoftLimit(10).HardLimit(5),
+			fields:  []string{"hard_limit"},
+		},
+		{
+			name:    "duplicate domains",
+			builder: services.NewCreateRequestBuilder().HTTPS(8080).Domain("example.com").Domain("Example.com."),
+			fields:  []string{"domains[1].name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+
+			var verr *services.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+
+			if len(verr.Errors) != len(tt.fields) {
+				t.Fatalf("expected errors of fields %v, got %v", tt.fields, err)
+			}
+			for i, field := range tt.fields {
+				if verr.Errors[i].Field != field {
+					t.Errorf("expected an error of field %s, got %v", field, verr.Errors[i])
+				}
+			}
+		})
+	}
+}
@@ -31,6 +31,14 @@ func NewVolumesClientFromOptions(opts *options.Options) VolumesService {
 	}
 }
 
+// NewVolumesClientFromRequest instantiates a new volumes services client which
+// shares the just-in-time settings of an existing service request.
+func NewVolumesClientFromRequest(request *kcclient.ServiceRequest) VolumesService {
+	return &client{
+		request: request,
+	}
+}
+
 // WithMetro sets the just-in-time metro to use when connecting to the
 // KraftCloud API.
 func (c *client) WithMetro(m string) VolumesService {
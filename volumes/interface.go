@@ -26,7 +26,7 @@ type VolumesService interface {
 	// Get returns the current state and the configuration of volumes.
 	//
 	// See: https://docs.kraft.cloud/api/v1/volumes/#getting-the-status-of-a-volume
-	Get(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[GetResponseItem], error)
+	Get(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[GetResponseItem], error)
 
 	// Attach attaches a volume to an instance so that the volume is mounted
 	// when the instance starts using the volume and instance name.  The volume
@@ -34,7 +34,7 @@ type VolumesService interface {
 	// Currently, each instance can have only one volume attached at most.
 	//
 	// See: https://docs.kraft.cloud/api/v1/volumes/#attaching-a-volume-to-an-instance
-	Attach(ctx context.Context, volID, instance, at string, readOnly bool) (*kcclient.ServiceResponse[AttachResponseItem], error)
+	Attach(ctx context.Context, volID, instance kcclient.Ref, at string, readOnly bool) (*kcclient.ServiceResponse[AttachResponseItem], error)
 
 	// Detach detaches a volume from an instance.
 	// The instance from which to detach must in stopped state. If the volume
@@ -42,14 +42,14 @@ type VolumesService interface {
 	// make it persistent (i.e., it survives the deletion of the instance).
 	//
 	// See: https://docs.kraft.cloud/api/v1/volumes/#detaching-a-volume-from-an-instance
-	Detach(ctx context.Context, id string, from string) (*kcclient.ServiceResponse[DetachResponseItem], error)
+	Detach(ctx context.Context, id, from kcclient.Ref) (*kcclient.ServiceResponse[DetachResponseItem], error)
 
 	// Delete deletes the specified volume(s).
 	// Fails if any of the specified volumes is still attached to an instance.
 	// After this call the UUID of the volumes is no longer valid.
 	//
 	// See: https://docs.kraft.cloud/api/v1/volumes/#deleting-a-volume
-	Delete(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[DeleteResponseItem], error)
+	Delete(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[DeleteResponseItem], error)
 
 	// Lists all existing volumes. You can filter by persistence and volume
 	// state. The returned volumes fulfill all provided filter criteria. No
@@ -61,19 +61,19 @@ type VolumesService interface {
 	// CreateTemplate creates a new volume template with the given configuration.
 	//
 	// See: https://docs.kraft.cloud/api/v1/volumes/templates#creating-a-template
-	CreateTemplate(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[TemplateCreateResponseItem], error)
+	CreateTemplate(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[TemplateCreateResponseItem], error)
 
 	// GetTemplate returns the current state and the configuration of volume
 	// templates.
 	//
 	// See: https://docs.kraft.cloud/api/v1/volumes/templates#getting-the-status-of-a-template
-	GetTemplate(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[TemplateGetResponseItem], error)
+	GetTemplate(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[TemplateGetResponseItem], error)
 
 	// Delete deletes the specified template(s).
 	// After this call the UUID of the templates is no longer valid.
 	//
 	// See: https://docs.kraft.cloud/api/v1/volumes/templates#deleting-a-template
-	DeleteTemplate(ctx context.Context, ids ...string) (*kcclient.ServiceResponse[TemplateDeleteResponseItem], error)
+	DeleteTemplate(ctx context.Context, ids ...kcclient.Ref) (*kcclient.ServiceResponse[TemplateDeleteResponseItem], error)
 
 	// Lists all existing templates.
 	//
@@ -33,6 +33,11 @@ type CreateResponseItem struct {
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i CreateResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
 // GetResponseItem is a data item from a response to a GET /volumes request.
 // https://docs.kraft.cloud/api/v1/volumes/#getting-the-status-of-a-volume
 type GetResponseItem struct {
@@ -44,11 +49,16 @@ type GetResponseItem struct {
 	AttachedTo []InstanceAttachment `json:"attached_to"`
 	MountedBy  []InstanceMounting   `json:"mounted_by"`
 	Persistent bool                 `json:"persistent"`
-	CreatedAt  string               `json:"created_at"`
+	CreatedAt  kcclient.Timestamp   `json:"created_at"`
 
 	kcclient.APIResponseCommon
 }
 
+// Identity implements kcclient.Identified.
+func (i GetResponseItem) Identity() kcclient.Identity {
+	return kcclient.Identity{UUID: i.UUID, Name: i.Name}
+}
+
 type InstanceAttachment struct {
 	UUID string `json:"uuid,omitempty"`
 	Name string `json:"name,omitempty"`
@@ -121,7 +131,7 @@ type TemplateGetResponseItem struct {
 	AttachedTo  []InstanceAttachment `json:"attached_to"`
 	Persistent  bool                 `json:"persistent"`
 	QuotaPolicy string               `json:"quota_policy"`
-	CreatedAt   string               `json:"created_at"`
+	CreatedAt   kcclient.Timestamp   `json:"created_at"`
 
 	kcclient.APIResponseCommon
 }
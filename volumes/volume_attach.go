@@ -14,15 +14,14 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // Attach implements VolumesService.
-func (c *client) Attach(ctx context.Context, volID, instance, at string, readOnly bool) (*kcclient.ServiceResponse[AttachResponseItem], error) {
-	if volID == "" {
+func (c *client) Attach(ctx context.Context, volID, instance kcclient.Ref, at string, readOnly bool) (*kcclient.ServiceResponse[AttachResponseItem], error) {
+	if volID.IsZero() {
 		return nil, errors.New("volume identifier cannot be empty")
 	}
-	if instance == "" {
+	if instance.IsZero() {
 		return nil, errors.New("instance identifier cannot be empty")
 	}
 	if at == "" {
@@ -30,22 +29,12 @@ func (c *client) Attach(ctx context.Context, volID, instance, at string, readOnl
 	}
 
 	reqItem := make(map[string]any, 4)
-	if uuid.IsValid(volID) {
-		reqItem["uuid"] = volID
-	} else {
-		reqItem["name"] = volID
-	}
+	reqItem[volID.Attr()] = volID.Value()
 	reqItem["at"] = at
 	reqItem["readonly"] = readOnly
 
-	if uuid.IsValid(instance) {
-		reqItem["attach_to"] = map[string]any{
-			"uuid": instance,
-		}
-	} else {
-		reqItem["attach_to"] = map[string]any{
-			"name": instance,
-		}
+	reqItem["attach_to"] = map[string]any{
+		instance.Attr(): instance.Value(),
 	}
 
 	body, err := json.Marshal([]map[string]any{reqItem})
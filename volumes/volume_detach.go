@@ -14,27 +14,22 @@ import (
 	"net/http"
 
 	kcclient "sdk.kraft.cloud/client"
-	"sdk.kraft.cloud/uuid"
 )
 
 // Detach implements VolumesService.
-func (c *client) Detach(ctx context.Context, id string, from string) (*kcclient.ServiceResponse[DetachResponseItem], error) {
-	if id == "" {
+func (c *client) Detach(ctx context.Context, id, from kcclient.Ref) (*kcclient.ServiceResponse[DetachResponseItem], error) {
+	if id.IsZero() {
 		return nil, errors.New("identifier cannot be empty")
 	}
 
 	reqItem := make(map[string]any, 1)
-	if uuid.IsValid(id) {
-		reqItem["uuid"] = id
-	} else {
-		reqItem["name"] = id
-	}
+	reqItem[id.Attr()] = id.Value()
 
-	if from != "" {
-		if uuid.IsValid(from) {
-			reqItem["from"] = InstanceAttachment{UUID: from}
+	if !from.IsZero() {
+		if from.Attr() == "uuid" {
+			reqItem["from"] = InstanceAttachment{UUID: from.Value()}
 		} else {
-			reqItem["from"] = InstanceAttachment{Name: from}
+			reqItem["from"] = InstanceAttachment{Name: from.Value()}
 		}
 	}
 